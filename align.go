@@ -12,6 +12,9 @@ var (
 // Some common values for alignment, such as AlignStart, AlignCenter, and AlignEnd,
 // are given constants, but other values are possible.  For example, to align
 // a child with an position of 25%, use (AlignStart + AlignCenter) / 2.
+//
+// For a right-to-left layout, horizontal alignment is mirrored, so that the
+// start is on the right.
 type Alignment int16
 
 // Common values for alignment, representing the position of child widget.
@@ -51,7 +54,9 @@ func (*Align) Kind() *base.Kind {
 func (w *Align) Mount(parent base.Control) (base.Element, error) {
 	// Mount the child
 	child, err := base.Mount(parent, w.Child)
-	if err != nil {
+	if child == nil {
+		// When continuing after errors, a partially mounted child is
+		// returned along with the error, and should be kept.
 		return nil, err
 	}
 
@@ -62,7 +67,7 @@ func (w *Align) Mount(parent base.Control) (base.Element, error) {
 		heightFactor: w.HeightFactor,
 		hAlign:       w.HAlign,
 		vAlign:       w.VAlign,
-	}, nil
+	}, err
 }
 
 type alignElement struct {
@@ -73,6 +78,18 @@ type alignElement struct {
 	vAlign       Alignment
 	widthFactor  float64
 	heightFactor float64
+	bounds       base.Rectangle
+}
+
+func (w *alignElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *alignElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
 }
 
 func (w *alignElement) Close() {
@@ -87,7 +104,7 @@ func (*alignElement) Kind() *base.Kind {
 }
 
 func (w *alignElement) Layout(bc base.Constraints) base.Size {
-	size := w.child.Layout(bc.Loosen())
+	size := base.Layout(w.child, bc.Loosen())
 	w.childSize = size
 	if w.widthFactor > 0 {
 		size.Width = base.Length(float64(size.Width) * w.widthFactor)
@@ -114,15 +131,31 @@ func (w *alignElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return width
 }
 
+func (w *alignElement) Props() base.Widget {
+	return &Align{
+		HAlign:       w.hAlign,
+		VAlign:       w.vAlign,
+		WidthFactor:  w.widthFactor,
+		HeightFactor: w.heightFactor,
+		Child:        base.PropsOf(w.child),
+	}
+}
+
 func (w *alignElement) SetBounds(bounds base.Rectangle) {
-	x := bounds.Min.X.Scale(int(w.hAlign)-int(AlignEnd), int(AlignStart)-int(AlignEnd)) +
-		(bounds.Max.X-w.childSize.Width).Scale(int(w.hAlign)-int(AlignStart), int(AlignEnd)-int(AlignStart))
-	y := bounds.Min.Y.Scale(int(w.vAlign)-int(AlignEnd), int(AlignStart)-int(AlignEnd)) +
-		(bounds.Max.Y-w.childSize.Height).Scale(int(w.vAlign)-int(AlignStart), int(AlignEnd)-int(AlignStart))
-	w.child.SetBounds(base.Rectangle{
+	w.bounds = bounds
+	x := alignPosition(w.hAlign, bounds.Min.X, bounds.Max.X, w.childSize.Width)
+	y := alignPosition(w.vAlign, bounds.Min.Y, bounds.Max.Y, w.childSize.Height)
+	w.child.SetBounds(mirrorForDirection(w.parent, bounds, base.Rectangle{
 		base.Point{x, y},
 		base.Point{x + w.childSize.Width, y + w.childSize.Height},
-	})
+	}))
+}
+
+// alignPosition returns the start of a child with the specified size, when
+// aligned between start and end.
+func alignPosition(align Alignment, start, end, size base.Length) base.Length {
+	return start.Scale(int(align)-int(AlignEnd), int(AlignStart)-int(AlignEnd)) +
+		(end-size).Scale(int(align)-int(AlignStart), int(AlignEnd)-int(AlignStart))
 }
 
 func (w *alignElement) updateProps(data *Align) (err error) {
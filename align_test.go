@@ -8,21 +8,6 @@ import (
 	"bitbucket.org/rj/goey/mock"
 )
 
-func (w *alignElement) Props() base.Widget {
-	child := base.Widget(nil)
-	if w.child != nil {
-		child = w.child.(Proper).Props()
-	}
-
-	return &Align{
-		HAlign:       w.hAlign,
-		VAlign:       w.vAlign,
-		WidthFactor:  w.widthFactor,
-		HeightFactor: w.heightFactor,
-		Child:        child,
-	}
-}
-
 func TestAlignMount(t *testing.T) {
 	// These should all be able to mount without error.
 	testingMountWidgets(t,
@@ -146,3 +131,20 @@ func TestAlignMinIntrinsicSize(t *testing.T) {
 		}
 	}
 }
+
+func TestAlignRightToLeft(t *testing.T) {
+	child := mock.New(base.Size{10 * DIP, 20 * DIP})
+	elem := alignElement{
+		parent: base.Control{Context: (*base.Context)(nil).WithDirection(base.RightToLeft)},
+		child:  child,
+		hAlign: AlignStart,
+		vAlign: AlignStart,
+	}
+
+	// The start for horizontal alignment should be on the right.
+	elem.Layout(base.Tight(base.Size{100 * DIP, 100 * DIP}))
+	elem.SetBounds(base.Rect(0, 0, 100*DIP, 100*DIP))
+	if got, want := child.Bounds(), base.Rect(90*DIP, 0, 100*DIP, 20*DIP); got != want {
+		t.Errorf("Incorrect bounds for child, got %s, want %s", got, want)
+	}
+}
@@ -24,7 +24,7 @@ func (*Wipe) Kind() *base.Kind {
 // will be a child of the widget specified by parent.
 func (w *Wipe) Mount(parent base.Control) (base.Element, error) {
 	child, err := base.Mount(parent, w.Child)
-	if err != nil {
+	if child == nil {
 		return nil, err
 	}
 
@@ -32,7 +32,7 @@ func (w *Wipe) Mount(parent base.Control) (base.Element, error) {
 		child:  child,
 		parent: parent,
 		level:  w.Level,
-	}, nil
+	}, err
 }
 
 type wipeElement struct {
@@ -69,6 +69,18 @@ func (w *wipeElement) AnimateFrame(time Time) bool {
 	return true
 }
 
+func (w *wipeElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *wipeElement) Children() []base.Element {
+	// While the animation is running, the previous child is still visible.
+	if w.oldChild != nil {
+		return []base.Element{w.child, w.oldChild}
+	}
+	return []base.Element{w.child}
+}
+
 func (w *wipeElement) Close() {
 	w.child.Close()
 	if w.oldChild != nil {
@@ -82,7 +94,7 @@ func (*wipeElement) Kind() *base.Kind {
 }
 
 func (w *wipeElement) Layout(bc base.Constraints) base.Size {
-	return w.child.Layout(bc)
+	return base.Layout(w.child, bc)
 }
 
 func (w *wipeElement) MinIntrinsicHeight(width base.Length) base.Length {
@@ -93,6 +105,13 @@ func (w *wipeElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return w.child.MinIntrinsicWidth(height)
 }
 
+func (w *wipeElement) Props() base.Widget {
+	return &Wipe{
+		Child: base.PropsOf(w.child),
+		Level: w.level,
+	}
+}
+
 func (w *wipeElement) SetBounds(bounds base.Rectangle) {
 	if bounds == w.bounds {
 		return
@@ -129,7 +148,7 @@ func (w *wipeElement) updateProps(data *Wipe) error {
 	} else {
 		w.ease = NewEaseLength(0, -w.bounds.Dx())
 	}
-	child.Layout(base.Tight(base.Size{w.bounds.Dx(), w.bounds.Dy()}))
+	base.Layout(child, base.Tight(base.Size{w.bounds.Dx(), w.bounds.Dy()}))
 	child.SetBounds(w.bounds.Add(base.Point{w.ease.ca, 0}))
 
 	w.level = data.Level
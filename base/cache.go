@@ -0,0 +1,120 @@
+package base
+
+// layoutGeneration identifies the current state of the GUI for the purpose of
+// caching layout results.  Any cache recorded with an older generation is
+// stale.  The variable is only accessed from the GUI thread.
+var layoutGeneration uint64 = 1
+
+// maxCachedQueries limits the number of results for intrinsic size queries
+// that will be kept by a LayoutCache.  Layout algorithms only use a small
+// number of distinct arguments during a pass, typically including Inf.
+const maxCachedQueries = 4
+
+// InvalidateLayout discards all of the results stored in any LayoutCache.
+// Reconciling the GUI, through Mount, DiffChild, or DiffChildren, calls this
+// function automatically.  Elements only need to call this function if their
+// intrinsic size changes for some other reason, such as a change to the
+// system's font or scaling.
+func InvalidateLayout() {
+	layoutGeneration++
+}
+
+// LayoutCache stores the results of layout queries for an element, so that
+// containers do not repeat the work when they query their children several
+// times during a single pass, or when the window is resized.  Results for
+// MinIntrinsicHeight and MinIntrinsicWidth are kept for several arguments,
+// but only the most recent call to Layout is kept, since layout also
+// determines the positions used by SetBounds.
+//
+// The zero value is an empty cache.  Results are discarded whenever the GUI
+// is reconciled, and elements should call Invalidate when their properties
+// are updated.
+type LayoutCache struct {
+	generation uint64
+	minWidth   []cacheEntry
+	minHeight  []cacheEntry
+	hasLayout  bool
+	layoutIn   Constraints
+	layoutOut  Size
+}
+
+type cacheEntry struct {
+	arg, value Length
+}
+
+// Invalidate discards all of the results in the cache.
+func (c *LayoutCache) Invalidate() {
+	c.generation = 0
+	c.minWidth = c.minWidth[:0]
+	c.minHeight = c.minHeight[:0]
+	c.hasLayout = false
+}
+
+func (c *LayoutCache) check() {
+	if c.generation != layoutGeneration {
+		c.Invalidate()
+		c.generation = layoutGeneration
+	}
+}
+
+// Layout returns the size from the most recent layout, if it was performed
+// with the same constraints.
+func (c *LayoutCache) Layout(bc Constraints) (Size, bool) {
+	c.check()
+	if c.hasLayout && c.layoutIn == bc {
+		return c.layoutOut, true
+	}
+	return Size{}, false
+}
+
+// SetLayout records the size returned by layout for the constraints.
+func (c *LayoutCache) SetLayout(bc Constraints, size Size) {
+	c.check()
+	c.hasLayout = true
+	c.layoutIn = bc
+	c.layoutOut = size
+}
+
+// MinIntrinsicHeight returns the cached minimum intrinsic height for the
+// width, if available.
+func (c *LayoutCache) MinIntrinsicHeight(width Length) (Length, bool) {
+	c.check()
+	return lookupEntry(c.minHeight, width)
+}
+
+// SetMinIntrinsicHeight records the minimum intrinsic height for the width.
+func (c *LayoutCache) SetMinIntrinsicHeight(width, height Length) {
+	c.check()
+	c.minHeight = appendEntry(c.minHeight, width, height)
+}
+
+// MinIntrinsicWidth returns the cached minimum intrinsic width for the
+// height, if available.
+func (c *LayoutCache) MinIntrinsicWidth(height Length) (Length, bool) {
+	c.check()
+	return lookupEntry(c.minWidth, height)
+}
+
+// SetMinIntrinsicWidth records the minimum intrinsic width for the height.
+func (c *LayoutCache) SetMinIntrinsicWidth(height, width Length) {
+	c.check()
+	c.minWidth = appendEntry(c.minWidth, height, width)
+}
+
+func lookupEntry(entries []cacheEntry, arg Length) (Length, bool) {
+	for _, v := range entries {
+		if v.arg == arg {
+			return v.value, true
+		}
+	}
+	return 0, false
+}
+
+func appendEntry(entries []cacheEntry, arg, value Length) []cacheEntry {
+	if len(entries) >= maxCachedQueries {
+		// Discard the oldest result.
+		copy(entries, entries[1:])
+		entries = entries[:len(entries)-1]
+	}
+	return append(entries, cacheEntry{arg, value})
+}
@@ -0,0 +1,63 @@
+package base
+
+import (
+	"testing"
+)
+
+func TestLayoutCache(t *testing.T) {
+	cache := LayoutCache{}
+
+	// Empty cache
+	if _, ok := cache.Layout(Tight(Size{10, 20})); ok {
+		t.Errorf("Unexpected layout result in empty cache")
+	}
+	if _, ok := cache.MinIntrinsicHeight(Inf); ok {
+		t.Errorf("Unexpected min intrinsic height in empty cache")
+	}
+	if _, ok := cache.MinIntrinsicWidth(Inf); ok {
+		t.Errorf("Unexpected min intrinsic width in empty cache")
+	}
+
+	// Only the most recent layout is kept.
+	cache.SetLayout(Tight(Size{10, 20}), Size{10, 20})
+	cache.SetLayout(Loose(Size{30, 40}), Size{5, 6})
+	if _, ok := cache.Layout(Tight(Size{10, 20})); ok {
+		t.Errorf("Unexpected layout result for earlier constraints")
+	}
+	if size, ok := cache.Layout(Loose(Size{30, 40})); !ok || size != (Size{5, 6}) {
+		t.Errorf("Incorrect layout result, got %v (%v), want %v", size, ok, Size{5, 6})
+	}
+
+	// Intrinsic sizes are kept for several arguments.
+	for i := Length(0); i < maxCachedQueries+1; i++ {
+		cache.SetMinIntrinsicHeight(i*10, i)
+		cache.SetMinIntrinsicWidth(i*10, i+1)
+	}
+	if _, ok := cache.MinIntrinsicHeight(0); ok {
+		t.Errorf("Failed to discard oldest result")
+	}
+	for i := Length(1); i < maxCachedQueries+1; i++ {
+		if value, ok := cache.MinIntrinsicHeight(i * 10); !ok || value != i {
+			t.Errorf("Incorrect min intrinsic height, got %v (%v), want %v", value, ok, i)
+		}
+		if value, ok := cache.MinIntrinsicWidth(i * 10); !ok || value != i+1 {
+			t.Errorf("Incorrect min intrinsic width, got %v (%v), want %v", value, ok, i+1)
+		}
+	}
+
+	// Reconciling the GUI discards all results.
+	InvalidateLayout()
+	if _, ok := cache.Layout(Loose(Size{30, 40})); ok {
+		t.Errorf("Unexpected layout result after invalidation")
+	}
+	if _, ok := cache.MinIntrinsicHeight(10); ok {
+		t.Errorf("Unexpected min intrinsic height after invalidation")
+	}
+
+	// Results can also be discarded for a single cache.
+	cache.SetMinIntrinsicWidth(Inf, 10)
+	cache.Invalidate()
+	if _, ok := cache.MinIntrinsicWidth(Inf); ok {
+		t.Errorf("Unexpected min intrinsic width after invalidation")
+	}
+}
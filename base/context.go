@@ -0,0 +1,71 @@
+package base
+
+// Context carries values that are inherited by all of the descendants of a
+// widget, such as a theme, a locale, or a handle to application services.
+// Contexts form a chain, with each context adding a single key and value to
+// those provided by its ancestors.
+//
+// The context is carried alongside the platform handle in Control, so that
+// the values are available to widgets in Mount.  Elements that need the
+// values in UpdateProps should keep the parent Control used to mount them.
+//
+// A nil *Context is valid, and does not contain any values.
+type Context struct {
+	parent *Context
+	key    interface{}
+	value  interface{}
+}
+
+// WithValue returns a new context that provides value for key, and otherwise
+// inherits all of the values from c.  The key must be comparable, and, as
+// with the standard library's package context, should be of an unexported
+// type to avoid collisions.
+func (c *Context) WithValue(key, value interface{}) *Context {
+	return &Context{
+		parent: c,
+		key:    key,
+		value:  value,
+	}
+}
+
+// Value returns the value associated with key, as provided by the nearest
+// context in the chain.  If no context provides a value, Value returns nil.
+func (c *Context) Value(key interface{}) interface{} {
+	for ; c != nil; c = c.parent {
+		if c.key == key {
+			return c.value
+		}
+	}
+	return nil
+}
+
+// Parent returns the context from which c inherits its values.
+func (c *Context) Parent() *Context {
+	if c == nil {
+		return nil
+	}
+	return c.parent
+}
+
+// Update changes the value provided by this context.  Since the context is
+// shared by all descendants, the new value will be visible to all elements
+// that kept a reference to the context when they were mounted.
+//
+// Update should only be called by the owner of the context, typically a
+// provider widget when updating its properties.
+func (c *Context) Update(value interface{}) {
+	c.value = value
+}
+
+// Value returns the value associated with key in the control's context.  If no
+// value has been provided, Value returns nil.
+func (c Control) Value(key interface{}) interface{} {
+	return c.Context.Value(key)
+}
+
+// WithValue returns a copy of the control, with a context that also provides
+// value for key.
+func (c Control) WithValue(key, value interface{}) Control {
+	c.Context = c.Context.WithValue(key, value)
+	return c
+}
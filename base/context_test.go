@@ -0,0 +1,103 @@
+package base
+
+import (
+	"fmt"
+	"image"
+	"testing"
+)
+
+func ExampleContext() {
+	type key int
+	const themeKey key = 0
+
+	// A provider would add a context when mounting its children.
+	parent := Control{}.WithValue(themeKey, "dark")
+
+	// Descendants can then look up the value.
+	fmt.Println("The theme is", parent.Value(themeKey))
+
+	// Output:
+	// The theme is dark
+}
+
+func TestContext(t *testing.T) {
+	type key int
+
+	var root *Context
+	c1 := root.WithValue(key(1), "a")
+	c2 := c1.WithValue(key(2), "b")
+	c3 := c2.WithValue(key(1), "c")
+
+	cases := []struct {
+		ctx *Context
+		key interface{}
+		out interface{}
+	}{
+		{root, key(1), nil},
+		{c1, key(1), "a"},
+		{c1, key(2), nil},
+		{c2, key(1), "a"},
+		{c2, key(2), "b"},
+		{c3, key(1), "c"},
+		{c3, key(2), "b"},
+		{c3, 1, nil},
+	}
+
+	for i, v := range cases {
+		if out := v.ctx.Value(v.key); out != v.out {
+			t.Errorf("Case %d: Returned value does not match, got %v, want %v", i, out, v.out)
+		}
+	}
+
+	if got := c3.Parent(); got != c2 {
+		t.Errorf("Incorrect parent for context")
+	}
+	if got := root.Parent(); got != nil {
+		t.Errorf("Incorrect parent for nil context")
+	}
+
+	// Updating a context should be visible to all descendants.
+	c1.Update("d")
+	if out := c2.Value(key(1)); out != "d" {
+		t.Errorf("Returned value does not match after update, got %v, want %v", out, "d")
+	}
+	if out := c3.Value(key(1)); out != "c" {
+		t.Errorf("Returned value does not match after update, got %v, want %v", out, "c")
+	}
+}
+
+func TestContextDirection(t *testing.T) {
+	var root *Context
+	if out := root.Direction(); out != LeftToRight {
+		t.Errorf("Incorrect default direction, got %s, want %s", out, LeftToRight)
+	}
+
+	ctx := root.WithDirection(RightToLeft)
+	child := ctx.WithValue(0, "a")
+	if out := child.Direction(); out != RightToLeft {
+		t.Errorf("Incorrect inherited direction, got %s, want %s", out, RightToLeft)
+	}
+
+	ctx.Update(LeftToRight)
+	if out := child.Direction(); out != LeftToRight {
+		t.Errorf("Incorrect direction after update, got %s, want %s", out, LeftToRight)
+	}
+}
+
+func TestContextDPI(t *testing.T) {
+	var root *Context
+	if out := root.DPI(); out != (image.Point{96, 96}) {
+		t.Errorf("Incorrect default DPI, got %s, want %s", out, image.Point{96, 96})
+	}
+
+	ctx := root.WithDPI(image.Point{144, 144})
+	child := ctx.WithValue(0, "a")
+	if out := child.DPI(); out != (image.Point{144, 144}) {
+		t.Errorf("Incorrect inherited DPI, got %s, want %s", out, image.Point{144, 144})
+	}
+
+	ctx.Update(image.Point{192, 96})
+	if out := child.DPI(); out != (image.Point{192, 96}) {
+		t.Errorf("Incorrect DPI after update, got %s, want %s", out, image.Point{192, 96})
+	}
+}
@@ -21,6 +21,8 @@ func CloseElements(children []Element) {
 // If the rhs is nil, DiffChild will still return a non-nil element.  See
 // the function Method for more details.
 func DiffChild(parent Control, lhs Element, rhs Widget) (Element, error) {
+	InvalidateLayout()
+
 	// If the rhs is empty, then make sure we delete the lhs if necessary
 	if rhs == nil {
 		if lhs != nil {
@@ -35,15 +37,21 @@ func DiffChild(parent Control, lhs Element, rhs Widget) (Element, error) {
 	}
 
 	// Can we propagate properties rather than mounting a new element?
-	if kind1, kind2 := lhs.Kind(), rhs.Kind(); kind1 == kind2 {
+	if canUpdate(lhs, rhs) {
 		err := lhs.UpdateProps(rhs)
 		return lhs, err
 	}
 
-	// Need to replace the element.
+	// Need to replace the element.  If the parent's context is set to
+	// continue on error, a partially mounted element still replaces the
+	// current element, so that it is not leaked.
 	newChild, err := rhs.Mount(parent)
 	if err != nil {
-		return lhs, err
+		if newChild == nil || !parent.Context.ContinueOnError() {
+			return lhs, err
+		}
+		lhs.Close()
+		return newChild, err
 	}
 	lhs.Close()
 	return newChild, nil
@@ -62,7 +70,22 @@ func DiffChild(parent Control, lhs Element, rhs Widget) (Element, error) {
 //
 // DiffChildren will try to reuse the underlying array from lhs for the
 // returned slice.
+//
+// If any of the widgets or elements implement Keyed, and report a non-empty
+// key, then children are matched by key rather than by position.  Elements
+// whose key matches a widget will be updated and moved to the new position,
+// even if the order of the children has changed.  Widgets without a key are
+// matched, in order, against the remaining elements without a key.
+//
+// By default, DiffChildren stops at the first child that fails to mount or
+// update.  If the parent's context has been set to continue on error, all of
+// the remaining children are still reconciled, and the returned error will be
+// a MultiError listing every child that failed.  A widget that fails to mount
+// is replaced by an empty element, so that the returned slice still matches
+// the widgets in rhs.
 func DiffChildren(parent Control, lhs []Element, rhs []Widget) ([]Element, error) {
+	InvalidateLayout()
+
 	// If the new tree does not contain any children, then we can trivially
 	// match the tree by deleting the actual widgets.
 	if len(rhs) == 0 {
@@ -72,20 +95,34 @@ func DiffChildren(parent Control, lhs []Element, rhs []Widget) ([]Element, error
 		return nil, nil
 	}
 
+	continueOnError := parent.Context.ContinueOnError()
+	errs := MultiError(nil)
+
 	// If the old tree does not contain any children, then we can trivially
 	// match the tree by mounting all of the widgets.
 	if len(lhs) == 0 {
 		c := make([]Element, 0, len(rhs))
 
-		for _, v := range rhs {
+		for i, v := range rhs {
 			mountedChild, err := v.Mount(parent)
 			if err != nil {
-				return nil, err
+				if !continueOnError {
+					CloseElements(c)
+					return nil, err
+				}
+				errs = errs.Append(i, err)
+				mountedChild = placeholder(mountedChild)
 			}
 			c = append(c, mountedChild)
 		}
 
-		return c, nil
+		return c, errs.Err()
+	}
+
+	// If any of the children have a key, then we need to match elements
+	// and widgets using those keys.
+	if hasKeys(lhs, rhs) {
+		return diffChildrenKeyed(parent, lhs, rhs)
 	}
 
 	// Delete excessive children
@@ -102,12 +139,22 @@ func DiffChildren(parent Control, lhs []Element, rhs []Widget) ([]Element, error
 		if kind1, kind2 := lhs[i].Kind(), rhs[i].Kind(); kind1 == kind2 {
 			err := lhs[i].UpdateProps(rhs[i])
 			if err != nil {
-				return lhs, err
+				if !continueOnError {
+					return lhs, err
+				}
+				errs = errs.Append(i, err)
 			}
 		} else {
 			mountedWidget, err := rhs[i].Mount(parent)
 			if err != nil {
-				return lhs, err
+				if !continueOnError {
+					return lhs, err
+				}
+				errs = errs.Append(i, err)
+				if mountedWidget == nil {
+					// Keep the current element, as DiffChild does.
+					continue
+				}
 			}
 			lhs[i].Close()
 			lhs[i] = mountedWidget
@@ -115,13 +162,123 @@ func DiffChildren(parent Control, lhs []Element, rhs []Widget) ([]Element, error
 	}
 
 	// Mount any remaining children.
-	for _, v := range rhs[len(lhs):] {
-		mountedWidget, err := v.Mount(parent)
+	for i := len(lhs); i < len(rhs); i++ {
+		mountedWidget, err := rhs[i].Mount(parent)
 		if err != nil {
-			return lhs, err
+			if !continueOnError {
+				return lhs, err
+			}
+			errs = errs.Append(i, err)
+			mountedWidget = placeholder(mountedWidget)
 		}
 		lhs = append(lhs, mountedWidget)
 	}
 
-	return lhs, nil
+	return lhs, errs.Err()
+}
+
+func diffChildrenKeyed(parent Control, lhs []Element, rhs []Widget) ([]Element, error) {
+	// Build an index of the current elements.  Elements with a key can be
+	// found using the map, while elements without a key will be matched in
+	// order.
+	keys := make(map[string]int, len(lhs))
+	unkeyed := make([]int, 0, len(lhs))
+	for i, v := range lhs {
+		if key := KeyOf(v); key != "" {
+			keys[key] = i
+		} else {
+			unkeyed = append(unkeyed, i)
+		}
+	}
+
+	// Track which of the current elements have been sunk into the new list.
+	used := make([]bool, len(lhs))
+	c := make([]Element, 0, len(rhs))
+
+	continueOnError := parent.Context.ContinueOnError()
+	errs := MultiError(nil)
+
+	for j, v := range rhs {
+		// Find the current element, if any, that matches this widget.
+		ndx := -1
+		if key := KeyOf(v); key != "" {
+			if i, ok := keys[key]; ok && !used[i] {
+				ndx = i
+			}
+		} else if len(unkeyed) > 0 {
+			ndx, unkeyed = unkeyed[0], unkeyed[1:]
+		}
+
+		// Update or replace the matching element, or mount a new element
+		// if there is no match.
+		if ndx >= 0 && canUpdate(lhs[ndx], v) {
+			used[ndx] = true
+			c = append(c, lhs[ndx])
+			if err := lhs[ndx].UpdateProps(v); err != nil {
+				if !continueOnError {
+					return appendUnused(c, lhs, used), err
+				}
+				errs = errs.Append(j, err)
+			}
+			continue
+		}
+
+		mountedWidget, err := v.Mount(parent)
+		if err != nil {
+			if !continueOnError {
+				return appendUnused(c, lhs, used), err
+			}
+			errs = errs.Append(j, err)
+			mountedWidget = placeholder(mountedWidget)
+		}
+		c = append(c, mountedWidget)
+	}
+
+	// Delete any elements that were not matched.
+	for i, v := range lhs {
+		if !used[i] {
+			v.Close()
+		}
+	}
+
+	return c, errs.Err()
+}
+
+// appendUnused is used when reconciliation fails part way.  The elements
+// from lhs that have not yet been matched are appended to c so that the
+// caller retains responsibility for them.
+func appendUnused(c []Element, lhs []Element, used []bool) []Element {
+	for i, v := range lhs {
+		if !used[i] {
+			c = append(c, v)
+		}
+	}
+	return c
+}
+
+// placeholder returns an empty element if a widget failed to mount without
+// returning a partially mounted element.
+func placeholder(elem Element) Element {
+	if elem == nil {
+		return (*nilElement)(nil)
+	}
+	return elem
+}
+
+func canUpdate(lhs Element, rhs Widget) bool {
+	return lhs.Kind() == rhs.Kind() && KeyOf(lhs) == KeyOf(rhs)
+}
+
+func hasKeys(lhs []Element, rhs []Widget) bool {
+	for _, v := range lhs {
+		if KeyOf(v) != "" {
+			return true
+		}
+	}
+	for _, v := range rhs {
+		if KeyOf(v) != "" {
+			return true
+		}
+	}
+	return false
 }
@@ -7,9 +7,15 @@ import (
 )
 
 type mock struct {
-	kind *Kind
-	err  error
-	Prop int
+	kind    *Kind
+	err     error
+	partial bool // If set, the element is still returned when mounting fails.
+	key     string
+	Prop    int
+}
+
+func (m *mock) GetKey() string {
+	return m.key
 }
 
 func (m *mock) Kind() *Kind {
@@ -18,28 +24,38 @@ func (m *mock) Kind() *Kind {
 
 func (m *mock) Mount(parent Control) (Element, error) {
 	// Check if the mock widget is supposed to fail with an error when mounted.
-	if m.err != nil {
+	if m.err != nil && !m.partial {
 		return nil, m.err
 	}
 
 	// Create the mock element.
 	return &mockElement{
 		kind: m.kind,
+		key:  m.key,
 		Prop: m.Prop,
-	}, nil
+	}, m.err
 }
 
 type mockElement struct {
 	kind   *Kind
 	err    error
+	key    string
 	Closed bool
 	Prop   int
 }
 
+func (m *mockElement) Bounds() Rectangle {
+	return Rectangle{}
+}
+
 func (m *mockElement) Close() {
 	m.Closed = true
 }
 
+func (m *mockElement) GetKey() string {
+	return m.key
+}
+
 func (m *mockElement) Kind() *Kind {
 	return m.kind
 }
@@ -124,6 +140,10 @@ func TestDiffChild(t *testing.T) {
 		{&mockElement{kind: &kind1, Prop: 3}, &mock{kind: &kind2, Prop: 13}, &mockElement{kind: &kind2, Prop: 13}, nil, true},
 		// Update existing element
 		{&mockElement{kind: &kind1, Prop: 3}, &mock{kind: &kind1, Prop: 13}, &mockElement{kind: &kind1, Prop: 13}, nil, false},
+		{&mockElement{kind: &kind1, key: "a", Prop: 3}, &mock{kind: &kind1, key: "a", Prop: 13}, &mockElement{kind: &kind1, key: "a", Prop: 13}, nil, false},
+		// Replace existing element with a different key
+		{&mockElement{kind: &kind1, key: "a", Prop: 3}, &mock{kind: &kind1, key: "b", Prop: 13}, &mockElement{kind: &kind1, key: "b", Prop: 13}, nil, true},
+		{&mockElement{kind: &kind1, Prop: 3}, &mock{kind: &kind1, key: "b", Prop: 13}, &mockElement{kind: &kind1, key: "b", Prop: 13}, nil, true},
 		// Fail to mount
 		{nil, &mock{kind: &kind1, err: err1}, nil, err1, false},
 		{nil, &mock{kind: &kind1, err: err2}, nil, err2, false},
@@ -152,6 +172,38 @@ func TestDiffChild(t *testing.T) {
 	}
 }
 
+func TestDiffChildContinueOnError(t *testing.T) {
+	kind1 := NewKind("bitbucket.org/rj/goey/base.Mock1")
+	kind2 := NewKind("bitbucket.org/rj/goey/base.Mock2")
+	err1 := errors.New("fake error 1 for mounting widget")
+
+	cases := []struct {
+		lhs       Element
+		rhs       Widget
+		out       Element
+		lhsClosed bool
+	}{
+		// Fail to replace, without a partially mounted element
+		{&mockElement{kind: &kind1}, &mock{kind: &kind2, err: err1}, &mockElement{kind: &kind1}, false},
+		// Fail to replace, with a partially mounted element
+		{&mockElement{kind: &kind1}, &mock{kind: &kind2, err: err1, partial: true, Prop: 3}, &mockElement{kind: &kind2, Prop: 3}, true},
+	}
+
+	parent := Control{Context: (*Context)(nil).WithContinueOnError(true)}
+	for i, v := range cases {
+		out, err := DiffChild(parent, v.lhs, v.rhs)
+		if err != err1 {
+			t.Errorf("Case %d: Returned error does not match, got %v, want %v", i, err, err1)
+		}
+		if !reflect.DeepEqual(out, v.out) {
+			t.Errorf("Case %d: Returned element does not match, got %v, want %v", i, out, v.out)
+		}
+		if closed := v.lhs.(*mockElement).Closed; closed != v.lhsClosed {
+			t.Errorf("Case %d: Incorrect state for lhs, got closed %v, want %v", i, closed, v.lhsClosed)
+		}
+	}
+}
+
 func TestDiffChildren(t *testing.T) {
 	kind1 := NewKind("bitbucket.org/rj/goey/base.Mock1")
 	kind2 := NewKind("bitbucket.org/rj/goey/base.Mock2")
@@ -264,6 +316,162 @@ func TestDiffChildren(t *testing.T) {
 	}
 }
 
+func TestDiffChildrenKeyed(t *testing.T) {
+	kind1 := NewKind("bitbucket.org/rj/goey/base.Mock1")
+	kind2 := NewKind("bitbucket.org/rj/goey/base.Mock2")
+	err1 := errors.New("fake error 1 for mounting widget")
+
+	a := &mockElement{kind: &kind1, key: "a", Prop: 1}
+	b := &mockElement{kind: &kind1, key: "b", Prop: 2}
+	c := &mockElement{kind: &kind1, key: "c", Prop: 3}
+	u := &mockElement{kind: &kind2, Prop: 4}
+
+	cases := []struct {
+		lhs    []Element
+		rhs    []Widget
+		out    []Element
+		err    error
+		reused []int // For each output element, the index into lhs, or -1
+	}{
+		// Insert at the start
+		{
+			[]Element{a, b},
+			[]Widget{&mock{kind: &kind1, key: "c", Prop: 3}, &mock{kind: &kind1, key: "a", Prop: 1}, &mock{kind: &kind1, key: "b", Prop: 2}},
+			[]Element{c, a, b},
+			nil, []int{-1, 0, 1},
+		},
+		// Remove from the start
+		{
+			[]Element{a, b, c},
+			[]Widget{&mock{kind: &kind1, key: "b", Prop: 2}, &mock{kind: &kind1, key: "c", Prop: 3}},
+			[]Element{b, c},
+			nil, []int{1, 2},
+		},
+		// Reorder and update
+		{
+			[]Element{a, b, c},
+			[]Widget{&mock{kind: &kind1, key: "c", Prop: 13}, &mock{kind: &kind1, key: "b", Prop: 12}, &mock{kind: &kind1, key: "a", Prop: 11}},
+			[]Element{
+				&mockElement{kind: &kind1, key: "c", Prop: 13},
+				&mockElement{kind: &kind1, key: "b", Prop: 12},
+				&mockElement{kind: &kind1, key: "a", Prop: 11},
+			},
+			nil, []int{2, 1, 0},
+		},
+		// Mixed keyed and unkeyed children
+		{
+			[]Element{u, a, b},
+			[]Widget{&mock{kind: &kind1, key: "b", Prop: 2}, &mock{kind: &kind2, Prop: 4}},
+			[]Element{b, u},
+			nil, []int{2, 0},
+		},
+		// Same key, but a different kind
+		{
+			[]Element{a},
+			[]Widget{&mock{kind: &kind2, key: "a", Prop: 1}},
+			[]Element{&mockElement{kind: &kind2, key: "a", Prop: 1}},
+			nil, []int{-1},
+		},
+		// Fail to mount new element
+		{
+			[]Element{a, b},
+			[]Widget{&mock{kind: &kind1, key: "b", Prop: 2}, &mock{kind: &kind1, key: "c", err: err1}},
+			[]Element{b, a},
+			err1, []int{1, 0},
+		},
+	}
+
+	for i, v := range cases {
+		// The elements are shared between cases, so work on copies.
+		lhs := make([]Element, 0, len(v.lhs))
+		for _, elem := range v.lhs {
+			dup := *elem.(*mockElement)
+			lhs = append(lhs, &dup)
+		}
+
+		out, err := DiffChildren(Control{}, append([]Element(nil), lhs...), v.rhs)
+		if err != v.err {
+			t.Errorf("Case %d: Returned error does not match, got %v, want %v", i, err, v.err)
+		}
+		if !reflect.DeepEqual(out, v.out) {
+			t.Errorf("Case %d: Returned element does not match, got %v, want %v", i, out, v.out)
+		}
+		if len(out) != len(v.reused) {
+			continue
+		}
+
+		used := make([]bool, len(lhs))
+		for j, ndx := range v.reused {
+			if ndx < 0 {
+				continue
+			}
+			used[ndx] = true
+			if out[j] != lhs[ndx] {
+				t.Errorf("Case %d: Element was not reused, out[%d] and lhs[%d]", i, j, ndx)
+			}
+		}
+		for j, elem := range lhs {
+			if closed := elem.(*mockElement).Closed; closed == used[j] {
+				t.Errorf("Case %d: Incorrect closed state for lhs[%d], got %v", i, j, closed)
+			}
+		}
+	}
+}
+
+func TestDiffChildrenContinueOnError(t *testing.T) {
+	kind1 := NewKind("bitbucket.org/rj/goey/base.Mock1")
+	kind2 := NewKind("bitbucket.org/rj/goey/base.Mock2")
+	err1 := errors.New("fake error 1 for mounting widget")
+	err2 := errors.New("fake error 2 for mounting widget")
+
+	cases := []struct {
+		lhs []Element
+		rhs []Widget
+		out []Element
+		err MultiError
+	}{
+		// Mount
+		{
+			nil,
+			[]Widget{&mock{kind: &kind1, err: err1}, &mock{kind: &kind1, Prop: 1}, &mock{kind: &kind2, err: err2}},
+			[]Element{(*nilElement)(nil), &mockElement{kind: &kind1, Prop: 1}, (*nilElement)(nil)},
+			MultiError{{0, err1}, {2, err2}},
+		},
+		// Update existing elements
+		{
+			[]Element{&mockElement{kind: &kind1, err: err1}, &mockElement{kind: &kind1}},
+			[]Widget{&mock{kind: &kind1, Prop: 1}, &mock{kind: &kind1, Prop: 2}},
+			[]Element{&mockElement{kind: &kind1, err: err1}, &mockElement{kind: &kind1, Prop: 2}},
+			MultiError{{0, err1}},
+		},
+		// Fail to replace existing element, and to add new element
+		{
+			[]Element{&mockElement{kind: &kind1}},
+			[]Widget{&mock{kind: &kind2, err: err1}, &mock{kind: &kind1, Prop: 3}, &mock{kind: &kind2, err: err2}},
+			[]Element{&mockElement{kind: &kind1}, &mockElement{kind: &kind1, Prop: 3}, (*nilElement)(nil)},
+			MultiError{{0, err1}, {2, err2}},
+		},
+		// Keyed children
+		{
+			[]Element{&mockElement{kind: &kind1, key: "a"}, &mockElement{kind: &kind1, key: "b", err: err1}},
+			[]Widget{&mock{kind: &kind1, key: "b", Prop: 1}, &mock{kind: &kind1, key: "c", err: err2}, &mock{kind: &kind1, key: "a", Prop: 2}},
+			[]Element{&mockElement{kind: &kind1, key: "b", err: err1}, (*nilElement)(nil), &mockElement{kind: &kind1, key: "a", Prop: 2}},
+			MultiError{{0, err1}, {1, err2}},
+		},
+	}
+
+	parent := Control{Context: (*Context)(nil).WithContinueOnError(true)}
+	for i, v := range cases {
+		out, err := DiffChildren(parent, append([]Element(nil), v.lhs...), v.rhs)
+		if !reflect.DeepEqual(err, v.err) {
+			t.Errorf("Case %d: Returned error does not match, got %v, want %v", i, err, v.err)
+		}
+		if !reflect.DeepEqual(out, v.out) {
+			t.Errorf("Case %d: Returned element does not match, got %v, want %v", i, out, v.out)
+		}
+	}
+}
+
 func TestLayout(t *testing.T) {
 	size1 := Size{96 * DIP, 2 * 96 * DIP}
 	cases := []struct {
@@ -0,0 +1,39 @@
+package base
+
+// Direction is the order in which children are positioned along a row.
+type Direction uint8
+
+// Allowed values for the layout direction.
+const (
+	LeftToRight Direction = iota // Children are positioned starting from the left.
+	RightToLeft                  // Children are positioned starting from the right.
+)
+
+// String returns a description of the direction.
+func (d Direction) String() string {
+	if d == RightToLeft {
+		return "RightToLeft"
+	}
+	return "LeftToRight"
+}
+
+type directionKey struct{}
+
+// WithDirection returns a new context that sets the layout direction.  The
+// returned context can be changed later by calling Update with a new
+// Direction.
+func (c *Context) WithDirection(value Direction) *Context {
+	return c.WithValue(directionKey{}, value)
+}
+
+// Direction returns the layout direction.  By default, layout is from left to
+// right.
+//
+// Containers should use the direction when positioning their children, so
+// that the start of a row, and the start of any horizontal alignment, is on
+// the right for a right-to-left layout.  The layout direction does not change
+// the intrinsic size of any element.
+func (c *Context) Direction() Direction {
+	value, _ := c.Value(directionKey{}).(Direction)
+	return value
+}
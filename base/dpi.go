@@ -0,0 +1,28 @@
+package base
+
+import (
+	"image"
+)
+
+type dpiKey struct{}
+
+// WithDPI returns a new context that sets the DPI (dots per inch) of the
+// monitor displaying the window.  The returned context can be changed later
+// by calling Update with a new image.Point.
+func (c *Context) WithDPI(value image.Point) *Context {
+	return c.WithValue(dpiKey{}, value)
+}
+
+// DPI returns the DPI (dots per inch) of the monitor displaying the window.
+// If no DPI has been set, DPI returns 96 in both directions, which is the
+// scale where one DIP is equal to one pixel.
+//
+// Unlike the package variable DPI, which is only valid during layout, the
+// value in the context is specific to each window, and so can be used at any
+// time with conversions such as PixelsXAt and FromPixelsXAt.
+func (c *Context) DPI() image.Point {
+	if value, ok := c.Value(dpiKey{}).(image.Point); ok {
+		return value
+	}
+	return image.Point{96, 96}
+}
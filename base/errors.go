@@ -0,0 +1,87 @@
+package base
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ChildError records an error when mounting or updating one child of a
+// container during reconciliation.
+type ChildError struct {
+	Index int   // Position of the child amongst the widgets for its parent.
+	Err   error // Error returned when mounting or updating the child.
+}
+
+// Error returns a description of the error, including the child's index.
+func (e *ChildError) Error() string {
+	return "child " + strconv.Itoa(e.Index) + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ChildError) Unwrap() error {
+	return e.Err
+}
+
+// MultiError collects the errors for all children that failed to mount or
+// update.  It is only returned when reconciliation is continuing after errors.
+// See the method ContinueOnError for Context.
+type MultiError []*ChildError
+
+// Error returns a description of all of the errors.
+func (e MultiError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, v := range e {
+		msgs = append(msgs, v.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the errors for the individual children.
+func (e MultiError) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, v := range e {
+		errs = append(errs, v)
+	}
+	return errs
+}
+
+// Append records an error for the child at index.  If err is nil, the list of
+// errors is not modified.
+func (e MultiError) Append(index int, err error) MultiError {
+	if err == nil {
+		return e
+	}
+	return append(e, &ChildError{Index: index, Err: err})
+}
+
+// Err returns nil if the list of errors is empty.  Otherwise, it returns e.
+// This should be used when returning, to avoid returning a non-nil error
+// interface that holds an empty list.
+func (e MultiError) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+type continueOnErrorKey struct{}
+
+// WithContinueOnError returns a new context that sets whether reconciliation
+// should continue after a child fails to mount or update.  The returned
+// context can be changed later by calling Update with a new boolean.
+func (c *Context) WithContinueOnError(value bool) *Context {
+	return c.WithValue(continueOnErrorKey{}, value)
+}
+
+// ContinueOnError returns true if reconciliation should continue after a child
+// fails to mount or update.  By default, reconciliation stops at the first
+// error.
+//
+// When continuing, containers should mount or update all of their children,
+// and then return a MultiError listing the children that failed.  A container
+// may return a non-nil element from Mount along with the error, in which case
+// the caller should keep the partially mounted element.
+func (c *Context) ContinueOnError() bool {
+	value, _ := c.Value(continueOnErrorKey{}).(bool)
+	return value
+}
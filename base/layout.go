@@ -0,0 +1,25 @@
+package base
+
+// layoutObserver, if not nil, is called after every layout performed using
+// the function Layout.  The variable is only accessed from the GUI thread.
+var layoutObserver func(elem Element, bc Constraints, size Size)
+
+// Layout calls the method Layout on the element, and returns the size.
+// Containers should use this function, rather than calling the method
+// directly, when laying out their children, so that the constraints and size
+// for every element can be observed when debugging layout.
+func Layout(elem Element, bc Constraints) Size {
+	size := elem.Layout(bc)
+	if layoutObserver != nil {
+		layoutObserver(elem, bc, size)
+	}
+	return size
+}
+
+// SetLayoutObserver sets a function that will be called with the constraints
+// and resulting size after every call to Layout.  Set the observer to nil to
+// stop observing layout.  This is used to debug layout, and should only be
+// called from the GUI thread.
+func SetLayoutObserver(observer func(elem Element, bc Constraints, size Size)) {
+	layoutObserver = observer
+}
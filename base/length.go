@@ -10,6 +10,12 @@ var (
 	// DPI contains the current DPI (dots per inch) of the monitor.
 	// User code should not need to set this directly, as drivers will update
 	// this variable as necessary.
+	//
+	// Since windows may be on monitors with different DPI, this variable is
+	// only valid during layout for the window being updated.  Widgets that
+	// need the DPI outside of layout should use the DPI provided by their
+	// parent's Context, and the conversion functions that take the DPI
+	// explicitly.
 	DPI image.Point
 )
 
@@ -65,13 +71,25 @@ func (v Length) PC() float64 {
 // PixelsX converts the distance measurement in DIPs to physical pixels, based
 // on the current DPI settings for horizontal scaling.
 func (v Length) PixelsX() int {
-	return fixed.Int26_6(v.Scale(DPI.X, 96)).Round()
+	return v.PixelsXAt(DPI.X)
+}
+
+// PixelsXAt converts the distance measurement in DIPs to physical pixels,
+// based on the horizontal DPI specified by dpi.
+func (v Length) PixelsXAt(dpi int) int {
+	return fixed.Int26_6(v.Scale(dpi, 96)).Round()
 }
 
 // PixelsY converts the distance measurement in DIPs to physical pixels, based
 // on the current DPI settings for vertical scaling.
 func (v Length) PixelsY() int {
-	return fixed.Int26_6(v.Scale(DPI.Y, 96)).Round()
+	return v.PixelsYAt(DPI.Y)
+}
+
+// PixelsYAt converts the distance measurement in DIPs to physical pixels,
+// based on the vertical DPI specified by dpi.
+func (v Length) PixelsYAt(dpi int) int {
+	return fixed.Int26_6(v.Scale(dpi, 96)).Round()
 }
 
 // Scale scales the distance by the ratio of num:den.
@@ -87,13 +105,25 @@ func (v Length) String() string {
 // FromPixelsX converts a distance measurement in physical pixels to DIPs, based on
 // the current DPI settings for horizontal scaling.
 func FromPixelsX(pixels int) Length {
-	return Length(pixels<<6).Scale(96, DPI.X)
+	return FromPixelsXAt(pixels, DPI.X)
+}
+
+// FromPixelsXAt converts a distance measurement in physical pixels to DIPs,
+// based on the horizontal DPI specified by dpi.
+func FromPixelsXAt(pixels int, dpi int) Length {
+	return Length(pixels<<6).Scale(96, dpi)
 }
 
 // FromPixelsY converts a distance measurement in physical pixels to DIPs, based on
 // the current DPI settings for vertical scaling.
 func FromPixelsY(pixels int) Length {
-	return Length(pixels<<6).Scale(96, DPI.Y)
+	return FromPixelsYAt(pixels, DPI.Y)
+}
+
+// FromPixelsYAt converts a distance measurement in physical pixels to DIPs,
+// based on the vertical DPI specified by dpi.
+func FromPixelsYAt(pixels int, dpi int) Length {
+	return Length(pixels<<6).Scale(96, dpi)
 }
 
 // A Point is an X, Y coordinate pair. The axes increase right and down.  This
@@ -120,7 +150,13 @@ func (p Point) Sub(q Point) Point {
 
 // Pixels returns the vector with the X and Y coordinates measured in pixels.
 func (p Point) Pixels() image.Point {
-	return image.Point{p.X.PixelsX(), p.Y.PixelsY()}
+	return p.PixelsAt(DPI)
+}
+
+// PixelsAt returns the vector with the X and Y coordinates measured in pixels,
+// based on the DPI specified by dpi.
+func (p Point) PixelsAt(dpi image.Point) image.Point {
+	return image.Point{p.X.PixelsXAt(dpi.X), p.Y.PixelsYAt(dpi.Y)}
 }
 
 // A Rectangle contains the points with Min.X <= X < Max.X, Min.Y <= Y < Max.Y.
@@ -159,7 +195,13 @@ func (r Rectangle) Dy() Length {
 
 // Pixels returns the rectangle with the X and Y coordinates measured in pixels.
 func (r Rectangle) Pixels() image.Rectangle {
-	return image.Rectangle{r.Min.Pixels(), r.Max.Pixels()}
+	return r.PixelsAt(DPI)
+}
+
+// PixelsAt returns the rectangle with the X and Y coordinates measured in
+// pixels, based on the DPI specified by dpi.
+func (r Rectangle) PixelsAt(dpi image.Point) image.Rectangle {
+	return image.Rectangle{r.Min.PixelsAt(dpi), r.Max.PixelsAt(dpi)}
 }
 
 // Size returns r's width and height.
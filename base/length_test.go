@@ -92,6 +92,21 @@ func TestFromPixels(t *testing.T) {
 			t.Errorf("Unexpected conversion in FromPixelsY on case %d, got %v, want %v", i, got, v.lengthy)
 		}
 	}
+
+	// The explicit conversions should not depend on the global DPI.
+	DPI = image.Point{2 * 96, 2 * 96}
+	for i, v := range cases {
+		if got := FromPixelsXAt(v.pixelsx, v.dpix); got != v.lengthx {
+			t.Errorf("Unexpected conversion in FromPixelsXAt on case %d, got %v, want %v", i, got, v.lengthx)
+		}
+		if got := FromPixelsYAt(v.pixelsy, v.dpiy); got != v.lengthy {
+			t.Errorf("Unexpected conversion in FromPixelsYAt on case %d, got %v, want %v", i, got, v.lengthy)
+		}
+		want := Size{v.lengthx, v.lengthy}
+		if got := FromPixelsAt(v.pixelsx, v.pixelsy, image.Point{v.dpix, v.dpiy}); got != want {
+			t.Errorf("Unexpected conversion in FromPixelsAt on case %d, got %v, want %v", i, got, want)
+		}
+	}
 }
 
 func TestLength(t *testing.T) {
@@ -179,6 +194,17 @@ func TestLength_Pixels(t *testing.T) {
 			t.Errorf("Error in case %d, want %s, got %s", i, v.out, out)
 		}
 	}
+
+	// The explicit conversions should not depend on the global DPI.
+	DPI = image.Point{96, 96}
+	for i, v := range cases {
+		if out := v.in.PixelsAt(v.dpi); out != v.out {
+			t.Errorf("Error in case %d, want %s, got %s", i, v.out, out)
+		}
+		if out := (Rectangle{Max: v.in}).PixelsAt(v.dpi); out != (image.Rectangle{Max: v.out}) {
+			t.Errorf("Error in case %d, want %s, got %s", i, v.out, out)
+		}
+	}
 }
 
 func TestRectangle(t *testing.T) {
@@ -10,6 +10,7 @@ var (
 // an element with an intrinsic size of zero and no visible elements in the
 // GUI.
 func Mount(parent Control, widget Widget) (Element, error) {
+	InvalidateLayout()
 	if widget == nil {
 		return (*nilElement)(nil), nil
 	}
@@ -19,6 +20,10 @@ func Mount(parent Control, widget Widget) (Element, error) {
 type nilElement struct {
 }
 
+func (*nilElement) Bounds() Rectangle {
+	return Rectangle{}
+}
+
 func (*nilElement) Close() {
 
 }
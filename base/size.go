@@ -1,5 +1,9 @@
 package base
 
+import (
+	"image"
+)
+
 // Size represents the size of a rectangular element.
 type Size struct {
 	Width, Height Length
@@ -11,6 +15,12 @@ func FromPixels(x, y int) Size {
 	return Size{FromPixelsX(x), FromPixelsY(y)}
 }
 
+// FromPixelsAt converts the pixels into lengths based on the DPI specified by
+// dpi, and return the size.
+func FromPixelsAt(x, y int, dpi image.Point) Size {
+	return Size{FromPixelsXAt(x, dpi.X), FromPixelsYAt(y, dpi.Y)}
+}
+
 // IsZero returns true if the size is the zero value.
 func (s *Size) IsZero() bool {
 	return s.Width == 0 && s.Height == 0
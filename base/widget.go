@@ -60,9 +60,110 @@ type Element interface {
 	// MinIntrinsicWidth returns the minimum width that this element requires
 	// to be correctly displayed.
 	MinIntrinsicWidth(height Length) Length
+	// Bounds returns the position of the element, as set by the most recent
+	// call to SetBounds.  The position is relative to the parent control.
+	Bounds() Rectangle
 	// SetBounds updates the position of the widget.
 	SetBounds(bounds Rectangle)
 	// UpdateProps will update the properties of the widget.  The Kind for
 	// the parameter data must match the Kind for the interface.
 	UpdateProps(data Widget) error
 }
+
+// Keyed is an optional interface for widgets and elements that have an
+// identity that is independent of their position amongst their siblings.
+// When reconciling a list of children, DiffChildren will match widgets and
+// elements with equal keys, even if the children have been reordered.  This
+// preserves state in the GUI, such as focus or the position of the caret,
+// when children are inserted, removed, or moved.
+//
+// An element must report the same key as the widget used to mount it.  An
+// empty key is equivalent to not implementing this interface.
+type Keyed interface {
+	// GetKey returns the identity of the widget or element.
+	GetKey() string
+}
+
+// KeyOf returns the key for the widget or element, if it implements Keyed.
+// Otherwise, it returns an empty string.
+func KeyOf(v interface{}) string {
+	if keyed, ok := v.(Keyed); ok {
+		return keyed.GetKey()
+	}
+	return ""
+}
+
+// Proper is an optional interface for elements that can recreate a widget
+// describing their current state.  Any changes made by the user, such as
+// editing text, are included.  For containers, the widgets for the children
+// are recreated from the mounted children.  Updating an element with the
+// widget returned by Props should not change the GUI.
+//
+// All of the elements in this module implement Proper.  However, some
+// properties cannot be read back on all platforms.  For example, GTK does not
+// support placeholders in a multi-line text editor.
+type Proper interface {
+	// Props returns a widget that describes the element.
+	Props() Widget
+}
+
+// PropsOf returns a widget describing the element, if it implements Proper.
+// Otherwise, it returns nil.
+func PropsOf(elem Element) Widget {
+	if proper, ok := elem.(Proper); ok {
+		return proper.Props()
+	}
+	return nil
+}
+
+// Parent is an optional interface for elements that contain other elements.
+// Along with the method Bounds on Element, it allows tools to traverse a
+// mounted tree, for example to provide debugging overlays, to query the GUI
+// in tests, or to check accessibility.
+//
+// The returned slice is owned by the element, and should not be modified.
+// It is only valid until the next call to UpdateProps or Close.
+type Parent interface {
+	// Children returns the mounted children of the element.
+	Children() []Element
+}
+
+// ChildrenOf returns the children of the element, if it implements Parent.
+// Otherwise, it returns nil.
+func ChildrenOf(elem Element) []Element {
+	if parent, ok := elem.(Parent); ok {
+		return parent.Children()
+	}
+	return nil
+}
+
+// Walk traverses the tree of elements rooted at elem in depth-first order,
+// calling visit for each element.  If visit returns false, the children of
+// that element are skipped.
+func Walk(elem Element, visit func(Element) bool) {
+	if elem == nil || !visit(elem) {
+		return
+	}
+
+	for _, v := range ChildrenOf(elem) {
+		Walk(v, visit)
+	}
+}
+
+// Baseliner is an optional interface for elements that display text.  It
+// allows containers to align the text of neighbouring elements.
+type Baseliner interface {
+	// Baseline returns the distance from the top of the element to the
+	// baseline of its first line of text, when the element has the specified
+	// height.
+	Baseline(height Length) Length
+}
+
+// BaselineOf returns the baseline for the element, if it implements
+// Baseliner.  Otherwise, it returns false.
+func BaselineOf(elem Element, height Length) (Length, bool) {
+	if baseliner, ok := elem.(Baseliner); ok {
+		return baseliner.Baseline(height), true
+	}
+	return 0, false
+}
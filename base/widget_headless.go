@@ -0,0 +1,25 @@
+//go:build headless
+// +build headless
+
+package base
+
+// Control is an opaque type used as a platform-specific handle to a control
+// created using the platform GUI.  As an example, this will refer to a HWND
+// when targeting Windows, but a *GtkContainer when targeting GTK.
+//
+// When building with the tag headless, there is no platform GUI.  The handle
+// instead identifies the window that contains the control.
+//
+// Unless developping new widgets, users should not need to use this type.
+//
+// Any methods on this type will be platform specific.
+type Control struct {
+	Handle  interface{}
+	Context *Context // Values inherited from ancestor widgets.
+}
+
+// NativeElement contains platform-specific methods that all widgets
+// must support.  When building with the tag headless, there are no
+// additional methods.
+type NativeElement interface {
+}
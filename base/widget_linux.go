@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package base
 
 import "github.com/gotk3/gotk3/gtk"
@@ -10,7 +13,8 @@ import "github.com/gotk3/gotk3/gtk"
 //
 // Any methods on this type will be platform specific.
 type Control struct {
-	Handle *gtk.Container
+	Handle  *gtk.Container
+	Context *Context // Values inherited from ancestor widgets.
 }
 
 // NativeElement contains platform-specific methods that all widgets
@@ -2,6 +2,8 @@ package base
 
 import (
 	"fmt"
+	"reflect"
+	"testing"
 )
 
 func ExampleKind_String() {
@@ -12,3 +14,86 @@ func ExampleKind_String() {
 	// Output:
 	// Kind is bitbucket.org/rj/goey/base.Example
 }
+
+type mockParent struct {
+	mockElement
+	children []Element
+}
+
+func (m *mockParent) Children() []Element {
+	return m.children
+}
+
+func TestWalk(t *testing.T) {
+	kind := NewKind("bitbucket.org/rj/goey/base.Mock")
+
+	leaf1 := &mockElement{kind: &kind, Prop: 2}
+	leaf2 := &mockElement{kind: &kind, Prop: 4}
+	inner := &mockParent{mockElement{kind: &kind, Prop: 3}, []Element{leaf2}}
+	root := &mockParent{mockElement{kind: &kind, Prop: 1}, []Element{leaf1, inner}}
+
+	prop := func(elem Element) int {
+		if p, ok := elem.(*mockParent); ok {
+			return p.Prop
+		}
+		return elem.(*mockElement).Prop
+	}
+
+	cases := []struct {
+		root Element
+		skip int
+		out  []int
+	}{
+		{nil, 0, nil},
+		{leaf1, 0, []int{2}},
+		{root, 0, []int{1, 2, 3, 4}},
+		{root, 3, []int{1, 2, 3}},
+		{root, 1, []int{1}},
+	}
+
+	for i, v := range cases {
+		out := []int(nil)
+		Walk(v.root, func(elem Element) bool {
+			out = append(out, prop(elem))
+			return prop(elem) != v.skip
+		})
+		if !reflect.DeepEqual(out, v.out) {
+			t.Errorf("Case %d: Visited elements do not match, got %v, want %v", i, out, v.out)
+		}
+	}
+
+	if got := ChildrenOf(leaf1); got != nil {
+		t.Errorf("Unexpected children for leaf, got %v", got)
+	}
+	if got := ChildrenOf(root); len(got) != 2 {
+		t.Errorf("Incorrect number of children for root, got %d", len(got))
+	}
+}
+
+type mockProper struct {
+	mockElement
+}
+
+func (m *mockProper) Props() Widget {
+	return &mock{kind: m.kind, Prop: m.Prop}
+}
+
+func TestPropsOf(t *testing.T) {
+	kind := NewKind("bitbucket.org/rj/goey/base.Mock")
+
+	cases := []struct {
+		in  Element
+		out Widget
+	}{
+		{nil, nil},
+		{(*nilElement)(nil), nil},
+		{&mockElement{kind: &kind, Prop: 1}, nil},
+		{&mockProper{mockElement{kind: &kind, Prop: 2}}, &mock{kind: &kind, Prop: 2}},
+	}
+
+	for i, v := range cases {
+		if out := PropsOf(v.in); !reflect.DeepEqual(out, v.out) {
+			t.Errorf("Case %d: Returned widget does not match, got %v, want %v", i, out, v.out)
+		}
+	}
+}
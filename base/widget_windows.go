@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package base
 
 import (
@@ -12,7 +15,8 @@ import (
 //
 // Any methods on this type will be platform specific.
 type Control struct {
-	HWnd win.HWND
+	HWnd    win.HWND
+	Context *Context // Values inherited from ancestor widgets.
 }
 
 // NativeElement contains platform-specific methods that all widgets
@@ -0,0 +1,78 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type buttonElement struct {
+	Control
+
+	text      string
+	disabled  bool
+	isDefault bool
+	onClick   func()
+}
+
+func (w *Button) mount(parent base.Control) (base.Element, error) {
+	retval := &buttonElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *buttonElement) Baseline(height base.Length) base.Length {
+	return centeredBaseline(height)
+}
+
+func (w *buttonElement) Click() {
+	if w.disabled {
+		return
+	}
+	if w.onClick != nil {
+		w.onClick()
+	}
+}
+
+func (w *buttonElement) Props() base.Widget {
+	return &Button{
+		Text:     w.text,
+		Disabled: w.disabled,
+		Default:  w.isDefault,
+		OnClick:  w.onClick,
+		OnFocus:  w.onFocus,
+		OnBlur:   w.onBlur,
+	}
+}
+
+func (w *buttonElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *buttonElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 23 * DIP
+}
+
+func (w *buttonElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return max(
+		75*DIP,
+		measureText(w.text).Width+7*DIP,
+	)
+}
+
+func (w *buttonElement) updateProps(data *Button) error {
+	w.text = data.Text
+	w.disabled = data.Disabled
+	w.isDefault = data.Default
+	w.canFocus = !data.Disabled
+	w.onClick = data.OnClick
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -32,7 +35,7 @@ func (w *Button) mount(parent base.Control) (base.Element, error) {
 
 	// Create the element
 	retval := &buttonElement{
-		Control: Control{&control.Widget},
+		Control: Control{handle: &control.Widget},
 	}
 
 	// Connect all callbacks for the events
@@ -48,6 +51,10 @@ func buttonOnDestroy(widget *gtk.Button, mounted *buttonElement) {
 	mounted.handle = nil
 }
 
+func (w *buttonElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *buttonElement) button() *gtk.Button {
 	return (*gtk.Button)(unsafe.Pointer(w.handle))
 }
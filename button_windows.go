@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -40,7 +43,7 @@ func (w *Button) mount(parent base.Control) (base.Element, error) {
 	subclassWindowProcedure(hwnd, &button.oldWindowProc, buttonWindowProc)
 
 	retval := &buttonElement{
-		Control: Control{hwnd},
+		Control: Control{hWnd: hwnd},
 		text:    text,
 		onClick: w.OnClick,
 		onFocus: w.OnFocus,
@@ -60,6 +63,10 @@ type buttonElement struct {
 	onBlur  func()
 }
 
+func (w *buttonElement) Baseline(height base.Length) base.Length {
+	return w.centeredBaseline(height)
+}
+
 func (w *buttonElement) Click() {
 	win.SendMessage(w.hWnd, win.BM_CLICK, 0, 0)
 }
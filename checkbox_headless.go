@@ -0,0 +1,76 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type checkboxElement struct {
+	Control
+
+	text     string
+	value    bool
+	disabled bool
+	onChange func(bool)
+}
+
+func (w *Checkbox) mount(parent base.Control) (base.Element, error) {
+	retval := &checkboxElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *checkboxElement) Baseline(height base.Length) base.Length {
+	return centeredBaseline(height)
+}
+
+func (w *checkboxElement) Click() {
+	if w.disabled {
+		return
+	}
+	w.value = !w.value
+	if w.onChange != nil {
+		w.onChange(w.value)
+	}
+}
+
+func (w *checkboxElement) Props() base.Widget {
+	return &Checkbox{
+		Text:     w.text,
+		Value:    w.value,
+		Disabled: w.disabled,
+		OnChange: w.onChange,
+		OnFocus:  w.onFocus,
+		OnBlur:   w.onBlur,
+	}
+}
+
+func (w *checkboxElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *checkboxElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 17 * DIP
+}
+
+func (w *checkboxElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return measureText(w.text).Width + 17*DIP
+}
+
+func (w *checkboxElement) updateProps(data *Checkbox) error {
+	w.text = data.Text
+	w.value = data.Value
+	w.disabled = data.Disabled
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -32,7 +35,7 @@ func (w *Checkbox) mount(parent base.Control) (base.Element, error) {
 
 	// Create the element
 	retval := &checkboxElement{
-		Control:  Control{&control.Widget},
+		Control:  Control{handle: &control.Widget},
 		onChange: w.OnChange,
 	}
 
@@ -57,6 +60,10 @@ func checkboxOnDestroy(widget *gtk.CheckButton, mounted *checkboxElement) {
 	mounted.handle = nil
 }
 
+func (w *checkboxElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *checkboxElement) checkbutton() *gtk.CheckButton {
 	return (*gtk.CheckButton)(unsafe.Pointer(w.handle))
 }
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -25,7 +28,7 @@ func (w *Checkbox) mount(parent base.Control) (base.Element, error) {
 	subclassWindowProcedure(hwnd, &button.oldWindowProc, checkboxWindowProc)
 
 	retval := &checkboxElement{
-		Control:  Control{hwnd},
+		Control:  Control{hWnd: hwnd},
 		text:     text,
 		onChange: w.OnChange,
 		onFocus:  w.OnFocus,
@@ -44,6 +47,10 @@ type checkboxElement struct {
 	onBlur   func()
 }
 
+func (w *checkboxElement) Baseline(height base.Length) base.Length {
+	return w.centeredBaseline(height)
+}
+
 func (w *checkboxElement) Click() {
 	win.SendMessage(w.hWnd, win.BM_CLICK, 0, 0)
 }
@@ -0,0 +1,202 @@
+package goey
+
+import (
+	"sync/atomic"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	componentKind = base.NewKind("bitbucket.org/rj/goey.Component")
+)
+
+// Component describes a widget that manages its own local state.  When
+// mounted, the component creates its state by calling Init, and then creates
+// its child widget by calling Render.
+//
+// Callbacks in the child widget can modify the state using the methods on
+// ComponentState.  The component will call Render again, and then reconcile
+// only its own subtree, so that changes to local state do not require the
+// application to rebuild the entire tree and call Window.SetChild.
+//
+// When the component is updated with new properties, the local state is
+// preserved, and Render is called again.  Use Key to preserve the local state
+// when the component is moved amongst its siblings.
+type Component struct {
+	Key    string                            // Identity of the component amongst its siblings.
+	Init   func() interface{}                // Create the initial local state.
+	Render func(*ComponentState) base.Widget // Create the child widget from the local state.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Component) Kind() *base.Kind {
+	return &componentKind
+}
+
+// GetKey returns the identity of the component amongst its siblings.
+func (w *Component) GetKey() string {
+	return w.Key
+}
+
+// Mount creates the local state, and then mounts the widget returned by
+// Render.  The newly created widget will be a child of the widget specified
+// by parent.
+func (w *Component) Mount(parent base.Control) (base.Element, error) {
+	retval := &componentElement{
+		parent: parent,
+		key:    w.Key,
+		init:   w.Init,
+		render: w.Render,
+	}
+	retval.state.elem = retval
+	if w.Init != nil {
+		retval.state.Value = w.Init()
+	}
+
+	child, err := base.Mount(parent, w.Render(&retval.state))
+	if child == nil {
+		return nil, err
+	}
+	retval.child = child
+
+	return retval, err
+}
+
+// ComponentState holds the local state for a mounted Component.  Methods on
+// this type must be called from the GUI thread, which is the case for any
+// callback from a widget.  Otherwise, use loop.Do.
+type ComponentState struct {
+	// Value is the local state.  It is initialized by calling Init.
+	Value interface{}
+
+	elem *componentElement
+}
+
+// Lookup returns the value provided for key by an ancestor of the component.
+// If no ancestor provides a value, Lookup returns nil.  See Provider.
+func (s *ComponentState) Lookup(key interface{}) interface{} {
+	if s.elem == nil {
+		return nil
+	}
+	return s.elem.parent.Value(key)
+}
+
+// Set replaces the local state, and then updates the component.
+func (s *ComponentState) Set(value interface{}) error {
+	s.Value = value
+	return s.Update()
+}
+
+// Update calls Render, and then reconciles the component's child with the
+// returned widget.  This method should be called after the local state has
+// been modified in place.  Once the component has been closed, this method
+// does nothing.
+//
+// The layout of the enclosing window is updated, since the size of the
+// child may have changed.
+//
+// As with Window.SetChild, this method is not reentrant, and will return an
+// error if called while the GUI is being updated.
+func (s *ComponentState) Update() error {
+	if s.elem == nil {
+		return nil
+	}
+
+	if !atomic.CompareAndSwapUintptr(&insideSetChildren, 0, 1) {
+		return ErrSetChildrenNotReentrant
+	}
+	defer func() {
+		atomic.StoreUintptr(&insideSetChildren, 0)
+	}()
+
+	err := s.elem.rerender()
+	// Whether or not there has been an error, the layout for the window
+	// needs to be redone.
+	requestLayout(s.elem.parent)
+	return err
+}
+
+type componentElement struct {
+	parent base.Control
+	child  base.Element
+	key    string
+	init   func() interface{}
+	render func(*ComponentState) base.Widget
+	state  ComponentState
+	bounds base.Rectangle
+}
+
+func (w *componentElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *componentElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *componentElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	// Any later updates to the state should not modify the GUI.
+	w.state.elem = nil
+}
+
+func (w *componentElement) GetKey() string {
+	return w.key
+}
+
+func (*componentElement) Kind() *base.Kind {
+	return &componentKind
+}
+
+func (w *componentElement) Layout(bc base.Constraints) base.Size {
+	return base.Layout(w.child, bc)
+}
+
+func (w *componentElement) MinIntrinsicHeight(width base.Length) base.Length {
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *componentElement) MinIntrinsicWidth(height base.Length) base.Length {
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *componentElement) Props() base.Widget {
+	return &Component{
+		Key:    w.key,
+		Init:   w.init,
+		Render: w.render,
+	}
+}
+
+func (w *componentElement) rerender() (err error) {
+	w.child, err = base.DiffChild(w.parent, w.child, w.render(&w.state))
+	// The render may have mounted new controls, which must be hidden if an
+	// ancestor has hidden this component.
+	hideNewElements(w.parent, w.child)
+	return err
+}
+
+func (w *componentElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	w.child.SetBounds(bounds)
+}
+
+func (w *componentElement) updateProps(data *Component) error {
+	// The local state is preserved, but the render function may have
+	// captured new values from the parent.
+	w.key = data.Key
+	w.init = data.Init
+	w.render = data.Render
+	return w.rerender()
+}
+
+func (w *componentElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Component))
+}
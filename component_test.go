@@ -0,0 +1,166 @@
+package goey
+
+import (
+	"strconv"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+)
+
+func ExampleComponent() {
+	// The click count is stored as local state in the component.  Clicking
+	// the button only updates the component, and not the entire window.
+	counter := &Component{
+		Init: func() interface{} {
+			return 0
+		},
+		Render: func(state *ComponentState) base.Widget {
+			clickCount := state.Value.(int)
+			return &Button{
+				Text: "Clicked " + strconv.Itoa(clickCount) + " times",
+				OnClick: func() {
+					err := state.Set(clickCount + 1)
+					if err != nil {
+						panic(err)
+					}
+				},
+			}
+		},
+	}
+
+	// The component can be used as any other widget.
+	_ = &VBox{
+		AlignMain:  MainCenter,
+		AlignCross: CrossCenter,
+		Children:   []base.Widget{counter},
+	}
+}
+
+func testingCounter(label string) *Component {
+	return &Component{
+		Init: func() interface{} {
+			return 0
+		},
+		Render: func(state *ComponentState) base.Widget {
+			clickCount := state.Value.(int)
+			return &Button{
+				Text: label + strconv.Itoa(clickCount),
+				OnClick: func() {
+					state.Set(clickCount + 1)
+				},
+			}
+		},
+	}
+}
+
+func TestComponentClose(t *testing.T) {
+	testingCloseWidgets(t,
+		testingCounter("A"),
+		&Component{Render: func(*ComponentState) base.Widget { return nil }},
+	)
+}
+
+func TestComponentUpdate(t *testing.T) {
+	buttonText := func(window *Window, index int) string {
+		child := window.children()[index].(*componentElement).child
+		return child.(base.Proper).Props().(*Button).Text
+	}
+
+	init := func() error {
+		window, err := NewWindow(t.Name(), &VBox{Children: []base.Widget{
+			testingCounter("A"), testingCounter("B"),
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+
+		go func(window *Window) {
+			defer func() {
+				err := loop.Do(func() error {
+					window.Close()
+					return nil
+				})
+				if err != nil {
+					t.Errorf("Error in Do, %s", err)
+				}
+			}()
+
+			// Click the first counter twice.
+			for i := 0; i < 2; i++ {
+				err := loop.Do(func() error {
+					child := window.children()[0].(*componentElement).child
+					if elem, ok := child.(Clickable); ok {
+						elem.Click()
+					}
+					return nil
+				})
+				if err != nil {
+					t.Errorf("Error in Do, %s", err)
+				}
+			}
+
+			err := loop.Do(func() error {
+				if got := buttonText(window, 0); got != "A2" {
+					t.Errorf("Incorrect text after click, got %s, want %s", got, "A2")
+				}
+				if got := buttonText(window, 1); got != "B0" {
+					t.Errorf("Incorrect text for sibling, got %s, want %s", got, "B0")
+				}
+
+				// Updating the window should preserve the local state, but
+				// use the new render function.
+				err := window.SetChild(&VBox{Children: []base.Widget{
+					testingCounter("C"), testingCounter("D"),
+				}})
+				if err != nil {
+					t.Errorf("Failed to set child, %s", err)
+				}
+				if got := buttonText(window, 0); got != "C2" {
+					t.Errorf("Incorrect text after update, got %s, want %s", got, "C2")
+				}
+				return nil
+			})
+			if err != nil {
+				t.Errorf("Error in Do, %s", err)
+			}
+		}(window)
+
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestComponentStateAfterClose(t *testing.T) {
+	var state *ComponentState
+
+	init := func() error {
+		window, err := NewWindow(t.Name(), &Component{
+			Render: func(s *ComponentState) base.Widget {
+				state = s
+				return &Label{Text: "A"}
+			},
+		})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		window.Close()
+
+		// Once closed, updates should be ignored.
+		if err := state.Set(1); err != nil {
+			t.Errorf("Unexpected error after close, %s", err)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
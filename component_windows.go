@@ -0,0 +1,13 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"github.com/lxn/win"
+)
+
+func (w *componentElement) SetOrder(previous win.HWND) win.HWND {
+	previous = w.child.SetOrder(previous)
+	return previous
+}
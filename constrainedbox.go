@@ -0,0 +1,200 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	constrainedboxKind = base.NewKind("bitbucket.org/rj/goey.ConstrainedBox")
+)
+
+// ConstrainedBox describes a widget that imposes additional constraints on
+// its child widget.
+//
+// The fields MinWidth, MaxWidth, MinHeight, and MaxHeight limit the size of
+// the child.  A maximum of zero means that there is no limit.  If
+// AspectRatio is greater than zero, the box will attempt to keep the ratio of
+// its width to its height, while still meeting the constraints.
+//
+// If Intrinsic is set, the child is sized to its minimum intrinsic width, and
+// then to its minimum intrinsic height for that width.  This is useful to keep
+// a form compact when placed inside an Align.
+//
+// The additional constraints cannot loosen the constraints from the parent, so
+// the box will never be sized to violate the constraints from its parent.
+type ConstrainedBox struct {
+	MinWidth    base.Length // Minimum width of the child.
+	MaxWidth    base.Length // If greater than zero, maximum width of the child.
+	MinHeight   base.Length // Minimum height of the child.
+	MaxHeight   base.Length // If greater than zero, maximum height of the child.
+	AspectRatio float64     // If greater than zero, ratio of width to height.
+	Intrinsic   bool        // If true, size the child to its minimum intrinsic size.
+	Child       base.Widget // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*ConstrainedBox) Kind() *base.Kind {
+	return &constrainedboxKind
+}
+
+// Mount creates a constrained box for a child widget in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *ConstrainedBox) Mount(parent base.Control) (base.Element, error) {
+	// Mount the child
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	retval := &constrainedboxElement{
+		parent: parent,
+		child:  child,
+	}
+	retval.setProps(w)
+	return retval, err
+}
+
+type constrainedboxElement struct {
+	parent      base.Control
+	child       base.Element
+	constraints base.Constraints
+	aspectRatio float64
+	intrinsic   bool
+	bounds      base.Rectangle
+}
+
+func (w *constrainedboxElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *constrainedboxElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *constrainedboxElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*constrainedboxElement) Kind() *base.Kind {
+	return &constrainedboxKind
+}
+
+// enforce returns the additional constraints, adjusted so that they also
+// satisfy the constraints bc.
+func (w *constrainedboxElement) enforce(bc base.Constraints) base.Constraints {
+	minSize := base.Size{
+		bc.ConstrainWidth(w.constraints.Min.Width),
+		bc.ConstrainHeight(w.constraints.Min.Height),
+	}
+	return base.Constraints{
+		Min: minSize,
+		Max: base.Size{
+			max(minSize.Width, bc.ConstrainWidth(w.constraints.Max.Width)),
+			max(minSize.Height, bc.ConstrainHeight(w.constraints.Max.Height)),
+		},
+	}
+}
+
+func (w *constrainedboxElement) Layout(bc base.Constraints) base.Size {
+	bc = w.enforce(bc)
+
+	if w.intrinsic {
+		width := bc.ConstrainWidth(w.child.MinIntrinsicWidth(base.Inf))
+		height := bc.ConstrainHeight(w.child.MinIntrinsicHeight(width))
+		bc = bc.Tighten(base.Size{width, height})
+	}
+
+	if w.aspectRatio > 0 && !bc.IsTight() {
+		// Start with the largest width available, or the width of the child if
+		// the width is not bounded.
+		width := bc.Max.Width
+		if width == base.Inf {
+			width = base.Layout(w.child, bc).Width
+		}
+		size := base.Size{width, base.Length(float64(width) / w.aspectRatio)}
+		if size.Width > 0 && size.Height > 0 {
+			bc = base.Tight(bc.ConstrainAndAttemptToPreserveAspectRatio(size))
+		}
+	}
+
+	return base.Layout(w.child, bc)
+}
+
+func (w *constrainedboxElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if w.aspectRatio > 0 && width != base.Inf {
+		height := base.Length(float64(width) / w.aspectRatio)
+		return height.Clamp(w.constraints.Min.Height, w.constraints.Max.Height)
+	}
+
+	width = min(width, w.constraints.Max.Width)
+	height := w.child.MinIntrinsicHeight(width)
+	return height.Clamp(w.constraints.Min.Height, w.constraints.Max.Height)
+}
+
+func (w *constrainedboxElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if w.aspectRatio > 0 && height != base.Inf {
+		width := base.Length(float64(height) * w.aspectRatio)
+		return width.Clamp(w.constraints.Min.Width, w.constraints.Max.Width)
+	}
+
+	height = min(height, w.constraints.Max.Height)
+	width := w.child.MinIntrinsicWidth(height)
+	return width.Clamp(w.constraints.Min.Width, w.constraints.Max.Width)
+}
+
+func (w *constrainedboxElement) Props() base.Widget {
+	maxWidth, maxHeight := w.constraints.Max.Width, w.constraints.Max.Height
+	if maxWidth == base.Inf {
+		maxWidth = 0
+	}
+	if maxHeight == base.Inf {
+		maxHeight = 0
+	}
+
+	return &ConstrainedBox{
+		MinWidth:    w.constraints.Min.Width,
+		MaxWidth:    maxWidth,
+		MinHeight:   w.constraints.Min.Height,
+		MaxHeight:   maxHeight,
+		AspectRatio: w.aspectRatio,
+		Intrinsic:   w.intrinsic,
+		Child:       base.PropsOf(w.child),
+	}
+}
+
+func (w *constrainedboxElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	w.child.SetBounds(bounds)
+}
+
+func (w *constrainedboxElement) setProps(data *ConstrainedBox) {
+	w.constraints = base.Constraints{
+		Min: base.Size{data.MinWidth, data.MinHeight},
+		Max: base.Size{data.MaxWidth, data.MaxHeight},
+	}
+	if w.constraints.Max.Width <= 0 {
+		w.constraints.Max.Width = base.Inf
+	}
+	if w.constraints.Max.Height <= 0 {
+		w.constraints.Max.Height = base.Inf
+	}
+	w.aspectRatio = data.AspectRatio
+	w.intrinsic = data.Intrinsic
+}
+
+func (w *constrainedboxElement) updateProps(data *ConstrainedBox) (err error) {
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	w.setProps(data)
+	return err
+}
+
+func (w *constrainedboxElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*ConstrainedBox))
+}
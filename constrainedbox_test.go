@@ -0,0 +1,109 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestConstrainedBoxMount(t *testing.T) {
+	testingMountWidgets(t,
+		&ConstrainedBox{MinWidth: 100 * DIP, Child: &Button{Text: "A"}},
+		&ConstrainedBox{MaxWidth: 100 * DIP, MaxHeight: 50 * DIP, Child: &Button{Text: "B"}},
+		&ConstrainedBox{AspectRatio: 1.5, Child: &Button{Text: "C"}},
+		&ConstrainedBox{Intrinsic: true, Child: &Label{Text: "D"}},
+		&ConstrainedBox{},
+	)
+}
+
+func TestConstrainedBoxClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&ConstrainedBox{MinWidth: 100 * DIP, Child: &Button{Text: "A"}},
+		&ConstrainedBox{},
+	)
+}
+
+func TestConstrainedBoxUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&ConstrainedBox{MinWidth: 100 * DIP, Child: &Button{Text: "A"}},
+		&ConstrainedBox{AspectRatio: 1.5, Child: &Button{Text: "B"}},
+		&ConstrainedBox{},
+	}, []base.Widget{
+		&ConstrainedBox{MaxWidth: 100 * DIP, MaxHeight: 50 * DIP, Child: &Button{Text: "AB"}},
+		&ConstrainedBox{Intrinsic: true},
+		&ConstrainedBox{MinHeight: 10 * DIP, Child: &Label{Text: "C"}},
+	})
+}
+
+func TestConstrainedBoxLayout(t *testing.T) {
+	child := base.Size{20 * DIP, 10 * DIP}
+	loose := base.Loose(base.Size{100 * DIP, 100 * DIP})
+
+	cases := []struct {
+		in  ConstrainedBox
+		bc  base.Constraints
+		out base.Size
+	}{
+		{ConstrainedBox{}, loose, child},
+		{ConstrainedBox{MinWidth: 40 * DIP, MinHeight: 30 * DIP}, loose, base.Size{40 * DIP, 30 * DIP}},
+		{ConstrainedBox{MinWidth: 400 * DIP}, loose, base.Size{100 * DIP, 10 * DIP}},
+		{ConstrainedBox{MaxWidth: 10 * DIP, MaxHeight: 5 * DIP}, loose, base.Size{10 * DIP, 5 * DIP}},
+		{ConstrainedBox{MaxWidth: 10 * DIP}, base.Tight(base.Size{50 * DIP, 50 * DIP}), base.Size{50 * DIP, 50 * DIP}},
+		{ConstrainedBox{AspectRatio: 2}, loose, base.Size{100 * DIP, 50 * DIP}},
+		{ConstrainedBox{AspectRatio: 0.5}, loose, base.Size{50 * DIP, 100 * DIP}},
+		{ConstrainedBox{AspectRatio: 2}, base.Loose(base.Size{base.Inf, 100 * DIP}), base.Size{20 * DIP, 10 * DIP}},
+		{ConstrainedBox{Intrinsic: true}, base.Tight(base.Size{50 * DIP, 50 * DIP}), base.Size{50 * DIP, 50 * DIP}},
+		{ConstrainedBox{Intrinsic: true}, base.Expand(), child},
+		{ConstrainedBox{Intrinsic: true, MinWidth: 30 * DIP}, base.Expand(), base.Size{30 * DIP, 10 * DIP}},
+	}
+
+	for i, v := range cases {
+		elem := constrainedboxElement{
+			child: mock.New(child),
+		}
+		elem.setProps(&v.in)
+
+		if out := elem.Layout(v.bc); out != v.out {
+			t.Errorf("Case %d: Returned size does not match, got %v, want %v", i, out, v.out)
+		}
+	}
+}
+
+func TestConstrainedBoxMinIntrinsicSize(t *testing.T) {
+	child := base.Size{20 * DIP, 10 * DIP}
+
+	cases := []struct {
+		in  ConstrainedBox
+		out base.Size
+	}{
+		{ConstrainedBox{}, child},
+		{ConstrainedBox{MinWidth: 40 * DIP, MinHeight: 30 * DIP}, base.Size{40 * DIP, 30 * DIP}},
+		{ConstrainedBox{MaxWidth: 10 * DIP, MaxHeight: 5 * DIP}, base.Size{10 * DIP, 5 * DIP}},
+		{ConstrainedBox{AspectRatio: 2}, child},
+	}
+
+	for i, v := range cases {
+		elem := constrainedboxElement{
+			child: mock.New(child),
+		}
+		elem.setProps(&v.in)
+
+		if out := elem.MinIntrinsicWidth(base.Inf); out != v.out.Width {
+			t.Errorf("Case %d: Returned min intrinsic width does not match, got %v, want %v", i, out, v.out.Width)
+		}
+		if out := elem.MinIntrinsicHeight(base.Inf); out != v.out.Height {
+			t.Errorf("Case %d: Returned min intrinsic height does not match, got %v, want %v", i, out, v.out.Height)
+		}
+	}
+
+	// With an aspect ratio, the size along one axis follows from the other.
+	elem := constrainedboxElement{child: mock.New(child)}
+	elem.setProps(&ConstrainedBox{AspectRatio: 2})
+	if out := elem.MinIntrinsicHeight(40 * DIP); out != 20*DIP {
+		t.Errorf("Returned min intrinsic height does not match, got %v, want %v", out, 20*DIP)
+	}
+	if out := elem.MinIntrinsicWidth(40 * DIP); out != 80*DIP {
+		t.Errorf("Returned min intrinsic width does not match, got %v, want %v", out, 80*DIP)
+	}
+}
@@ -0,0 +1,63 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"time"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+type dateinputElement struct {
+	Control
+
+	value    time.Time
+	disabled bool
+	onChange func(time.Time)
+}
+
+func (w *DateInput) mount(parent base.Control) (base.Element, error) {
+	retval := &dateinputElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *dateinputElement) Props() base.Widget {
+	return &DateInput{
+		Value:    w.value,
+		Disabled: w.disabled,
+		OnChange: w.onChange,
+		OnFocus:  w.onFocus,
+		OnBlur:   w.onBlur,
+	}
+}
+
+func (w *dateinputElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *dateinputElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 23 * DIP
+}
+
+func (w *dateinputElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 75 * DIP
+}
+
+func (w *dateinputElement) updateProps(data *DateInput) error {
+	// Like the native controls, only the date is kept.
+	year, month, day := data.Value.Date()
+	w.value = time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+	w.disabled = data.Disabled
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
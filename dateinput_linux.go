@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -34,7 +37,7 @@ func (w *DateInput) mount(parent base.Control) (base.Element, error) {
 
 	// Create the element
 	retval := &dateinputElement{
-		Control:  Control{&control.Widget},
+		Control:  Control{handle: &control.Widget},
 		onChange: w.OnChange,
 	}
 
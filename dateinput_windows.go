@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -47,7 +50,7 @@ func (w *DateInput) mount(parent base.Control) (base.Element, error) {
 	subclassWindowProcedure(hwnd, &oldDateTimePickWindowProc, dateinputWindowProc)
 
 	retval := &dateinputElement{
-		Control:  Control{hwnd},
+		Control:  Control{hWnd: hwnd},
 		onChange: w.OnChange,
 		onFocus:  w.OnFocus,
 		onBlur:   w.OnBlur,
@@ -37,6 +37,13 @@ func (w *Decoration) Mount(parent base.Control) (base.Element, error) {
 	return w.mount(parent)
 }
 
+func (w *decorationElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
 func (*decorationElement) Kind() *base.Kind {
 	return &decorationKind
 }
@@ -46,7 +53,7 @@ func (w *decorationElement) Layout(bc base.Constraints) base.Size {
 	vinset := w.insets.Top + w.insets.Bottom
 
 	innerConstraints := bc.Inset(hinset, vinset)
-	w.childSize = w.child.Layout(innerConstraints)
+	w.childSize = base.Layout(w.child, innerConstraints)
 	return base.Size{
 		w.childSize.Width + hinset,
 		w.childSize.Height + vinset,
@@ -63,6 +70,12 @@ func (w *decorationElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return w.child.MinIntrinsicWidth(height) + hinset
 }
 
+func (w *decorationElement) Props() base.Widget {
+	widget := w.props()
+	widget.Child = base.PropsOf(w.child)
+	return widget
+}
+
 func (w *decorationElement) UpdateProps(data base.Widget) error {
 	// Forward to the platform-dependant code
 	return w.updateProps(data.(*Decoration))
@@ -0,0 +1,83 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"image/color"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+func (w *Decoration) mount(parent base.Control) (base.Element, error) {
+	retval := &decorationElement{
+		parent: parent,
+		fill:   w.Fill,
+		stroke: w.Stroke,
+		insets: w.Insets,
+		radius: w.Radius,
+	}
+
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+	retval.child = child
+
+	return retval, err
+}
+
+type decorationElement struct {
+	Control
+	parent base.Control
+	fill   color.RGBA
+	stroke color.RGBA
+	insets Insets
+	radius base.Length
+
+	child     base.Element
+	childSize base.Size
+}
+
+func (w *decorationElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.Control.Close()
+}
+
+func (w *decorationElement) props() *Decoration {
+	return &Decoration{
+		Fill:   w.fill,
+		Stroke: w.stroke,
+		Insets: w.insets,
+		Radius: w.radius,
+	}
+}
+
+func (w *decorationElement) SetBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+
+	insets := w.insets.forDirection(w.parent.Context)
+	bounds.Min.X += insets.Left
+	bounds.Min.Y += insets.Top
+	bounds.Max.X -= insets.Right
+	bounds.Max.Y -= insets.Bottom
+	w.child.SetBounds(bounds)
+}
+
+func (w *decorationElement) updateProps(data *Decoration) error {
+	w.fill = data.Fill
+	w.stroke = data.Stroke
+	w.insets = data.Insets
+	w.radius = data.Radius
+
+	child, err := base.DiffChild(w.parent, w.child, data.Child)
+	w.child = child
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -19,11 +22,12 @@ func (w *Decoration) mount(parent base.Control) (base.Element, error) {
 	parent.Handle.Add(control)
 
 	retval := &decorationElement{
-		handle: control,
-		fill:   w.Fill,
-		stroke: w.Stroke,
-		insets: w.Insets,
-		radius: w.Radius,
+		handle:  control,
+		fill:    w.Fill,
+		stroke:  w.Stroke,
+		insets:  w.Insets,
+		radius:  w.Radius,
+		context: parent.Context,
 	}
 
 	control.Connect("destroy", decorationOnDestroy, retval)
@@ -31,13 +35,15 @@ func (w *Decoration) mount(parent base.Control) (base.Element, error) {
 	control.Show()
 
 	child, err := base.Mount(parent, w.Child)
-	if err != nil {
+	if child == nil {
+		// The child failed to mount.  Note that, when continuing after
+		// errors, a partially mounted child will still be non-nil.
 		control.Destroy()
 		return nil, err
 	}
 	retval.child = child
 
-	return retval, nil
+	return retval, err
 }
 
 type decorationElement struct {
@@ -49,6 +55,8 @@ type decorationElement struct {
 
 	child     base.Element
 	childSize base.Size
+	context   *base.Context
+	bounds    base.Rectangle
 }
 
 func decorationOnDestroy(widget *gtk.DrawingArea, mounted *decorationElement) {
@@ -93,6 +101,10 @@ func decorationOnDraw(widget *gtk.DrawingArea, cr *cairo.Context, mounted *decor
 	return false
 }
 
+func (w *decorationElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
 func (w *decorationElement) Close() {
 	if w.child != nil {
 		w.child.Close()
@@ -113,14 +125,23 @@ func (w *decorationElement) props() *Decoration {
 	}
 }
 
+func (w *decorationElement) setHidden(value bool) {
+	// The child's controls are siblings of the drawing area, and so need to
+	// be hidden separately.
+	w.handle.SetVisible(!value)
+	setHiddenElements(w.child, value)
+}
+
 func (w *decorationElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	pixels := bounds.Pixels()
 	syscall.SetBounds(&w.handle.Widget, pixels.Min.X, pixels.Min.Y, pixels.Dx(), pixels.Dy())
 
-	bounds.Min.X += w.insets.Left
-	bounds.Min.Y += w.insets.Top
-	bounds.Max.X -= w.insets.Right
-	bounds.Max.Y -= w.insets.Bottom
+	insets := w.insets.forDirection(w.context)
+	bounds.Min.X += insets.Left
+	bounds.Min.Y += insets.Top
+	bounds.Max.X -= insets.Right
+	bounds.Max.Y -= insets.Bottom
 	w.child.SetBounds(bounds)
 }
 
@@ -133,7 +154,7 @@ func (w *decorationElement) updateProps(data *Decoration) error {
 	if err != nil {
 		return err
 	}
-	w.child, err = base.DiffChild(base.Control{(*gtk.Container)(unsafe.Pointer(parent))}, w.child, data.Child)
+	w.child, err = base.DiffChild(base.Control{Handle: (*gtk.Container)(unsafe.Pointer(parent)), Context: w.context}, w.child, data.Child)
 	if err != nil {
 		return err
 	}
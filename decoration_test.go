@@ -15,21 +15,12 @@ var (
 	red   = color.RGBA{0xcc, 0xaa, 0x88, 0xff}
 )
 
-func (w *decorationElement) Props() base.Widget {
-	widget := w.props()
-	if w.child != nil {
-		widget.Child = w.child.(Proper).Props()
-	}
-
-	return widget
-}
-
 func decorationChildWidget(child base.Element) base.Widget {
 	if child == nil {
 		return nil
 	}
 
-	return child.(Proper).Props()
+	return child.(base.Proper).Props()
 }
 
 func TestDecorationMount(t *testing.T) {
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -44,23 +47,24 @@ func (w *Decoration) mount(parent base.Control) (base.Element, error) {
 	}
 
 	retval := &decorationElement{
-		Control: Control{hwnd},
+		Control: Control{hWnd: hwnd},
 		fill:    w.Fill,
 		stroke:  w.Stroke,
 		insets:  w.Insets,
 		radius:  w.Radius,
 		hBrush:  createBrush(w.Fill),
 		hPen:    createPen(w.Stroke),
+		context: parent.Context,
 	}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
 
-	retval.child, err = base.Mount(base.Control{hwnd}, w.Child)
-	if err != nil {
+	retval.child, err = base.Mount(base.Control{HWnd: hwnd, Context: parent.Context}, w.Child)
+	if retval.child == nil {
 		win.DestroyWindow(hwnd)
 		return nil, err
 	}
 
-	return retval, nil
+	return retval, err
 }
 
 type decorationElement struct {
@@ -74,6 +78,7 @@ type decorationElement struct {
 
 	child     base.Element
 	childSize base.Size
+	context   *base.Context
 }
 
 func createBrush(clr color.RGBA) win.HBRUSH {
@@ -162,10 +167,11 @@ func (w *decorationElement) SetBounds(bounds base.Rectangle) {
 	px := base.FromPixelsX(1)
 	py := base.FromPixelsY(1)
 	position := bounds.Min
-	bounds.Min.X += px + w.insets.Left - position.X
-	bounds.Min.Y += py + w.insets.Top - position.Y
-	bounds.Max.X -= px + w.insets.Right + position.X
-	bounds.Max.Y -= py + w.insets.Bottom + position.Y
+	insets := w.insets.forDirection(w.context)
+	bounds.Min.X += px + insets.Left - position.X
+	bounds.Min.Y += py + insets.Top - position.Y
+	bounds.Max.X -= px + insets.Right + position.X
+	bounds.Max.Y -= py + insets.Bottom + position.Y
 	w.child.SetBounds(bounds)
 }
 
@@ -205,7 +211,7 @@ func (w *decorationElement) updateProps(data *Decoration) error {
 	w.insets = data.Insets
 	w.radius = data.Radius
 
-	child, err := base.DiffChild(base.Control{w.hWnd}, w.child, data.Child)
+	child, err := base.DiffChild(base.Control{HWnd: w.hWnd, Context: w.context}, w.child, data.Child)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,65 @@
+//go:build headless
+// +build headless
+
+package dialog
+
+import (
+	"path/filepath"
+)
+
+type dialogImpl struct {
+}
+
+var (
+	// Keys typed into the active dialog.  There is no user to respond to a
+	// dialog when headless, so dialogs block on the GUI thread until a
+	// response is typed.
+	activeDialogKeys = make(chan rune)
+)
+
+func typeKeys(text string) chan error {
+	err := make(chan error, 1)
+
+	go func() {
+		defer close(err)
+
+		for _, r := range text {
+			activeDialogKeys <- r
+		}
+	}()
+
+	return err
+}
+
+// waitForResponse blocks until the dialog is either accepted or cancelled.
+// Any other keys are collected, and returned as the text typed by the user.
+func waitForResponse() (text string, ok bool) {
+	buffer := []rune(nil)
+	for r := range activeDialogKeys {
+		switch r {
+		case '\n':
+			return string(buffer), true
+		case '\x1b':
+			return "", false
+		default:
+			buffer = append(buffer, r)
+		}
+	}
+
+	panic("not reachable")
+}
+
+func showFileDialog(filename string) (string, error) {
+	text, ok := waitForResponse()
+	if !ok {
+		return "", nil
+	}
+
+	if text != "" {
+		filename = text
+	}
+	if filename == "" {
+		return "", nil
+	}
+	return filepath.Abs(filename)
+}
@@ -0,0 +1,21 @@
+//go:build headless
+// +build headless
+
+package dialog
+
+func (m *Message) show() error {
+	waitForResponse()
+	return nil
+}
+
+func (m *Message) withError() {
+	m.icon = 1
+}
+
+func (m *Message) withWarn() {
+	m.icon = 2
+}
+
+func (m *Message) withInfo() {
+	m.icon = 3
+}
@@ -0,0 +1,8 @@
+//go:build headless
+// +build headless
+
+package dialog
+
+func (m *OpenFile) show() (string, error) {
+	return showFileDialog(m.filename)
+}
@@ -0,0 +1,8 @@
+//go:build headless
+// +build headless
+
+package dialog
+
+func (m *SaveFile) show() (string, error) {
+	return showFileDialog(m.filename)
+}
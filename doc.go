@@ -33,6 +33,16 @@
 //
 //      sudo apt-get install libgtk-3-dev
 //
+// Headless
+//
+// When built with the tag headless, widgets are mounted as in-memory elements
+// instead of platform controls.  The elements record their properties,
+// bounds, and callbacks, and the event loop runs without a display, so that
+// layout and interactions for entire applications can be tested on a CI
+// server.  Neither GTK+ 3 nor CGO is required.
+//
+//      go test -tags headless ./...
+//
 // Darwin (MacOS)
 //
 // A port to darwin using the Cocoa API is in the repository, but is only
@@ -30,6 +30,11 @@ func (w *Empty) Mount(parent base.Control) (base.Element, error) {
 }
 
 type emptyElement struct {
+	bounds base.Rectangle
+}
+
+func (w *emptyElement) Bounds() base.Rectangle {
+	return w.bounds
 }
 
 func (w *emptyElement) Close() {
@@ -64,6 +69,7 @@ func (w *emptyElement) MinIntrinsicWidth(height base.Length) base.Length {
 
 func (w *emptyElement) SetBounds(bounds base.Rectangle) {
 	// Virtual control, so no resource to resize
+	w.bounds = bounds
 }
 
 func (w *emptyElement) UpdateProps(data base.Widget) error {
@@ -1,9 +1,7 @@
 // This package provides an example application built using the goey package
 // that rebuilds the classic Tcl/Tk tutorial application.
 //
-// The example also shows the use of a custom layout container, MinSizedBox,
-// showing that new layouts can be developed outside of the main package,
-// and used portably.  In this case, the layout uses the methods
+// The example also shows the use of a ConstrainedBox, which uses the methods
 // MinIntrinsicHeight and MinIntrinsicWidth to find the minimum acceptable size
 // for the child, and then limits the child to that particular size as long as
 // it meets the layout constraints.
@@ -63,7 +61,7 @@ func update() {
 func render() base.Widget {
 	return &goey.Padding{
 		Insets: goey.DefaultInsets(),
-		Child: &goey.Align{Child: &MinSizedBox{Child: &goey.VBox{
+		Child: &goey.Align{Child: &goey.ConstrainedBox{Intrinsic: true, Child: &goey.VBox{
 			AlignMain: goey.MainCenter,
 			Children: []base.Widget{
 				&goey.HBox{
@@ -47,6 +47,15 @@ type columnElement struct {
 
 	minWidth   base.Length
 	rowHeights []base.Length
+	bounds     base.Rectangle
+}
+
+func (w *columnElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *columnElement) Children() []base.Element {
+	return w.children
 }
 
 func (w *columnElement) Close() {
@@ -141,6 +150,7 @@ func (w *columnElement) MinIntrinsicHeight(width base.Length) base.Length {
 func (w *columnElement) SetBounds(bounds base.Rectangle) {
 	const gap = 11 * base.DIP
 
+	w.bounds = bounds
 	if len(w.children) == 0 {
 		return
 	}
@@ -63,7 +63,7 @@ func renderSidebar() base.Widget {
 		Fill: color.RGBA{128, 255, 128, 255},
 		Child: &goey.Padding{
 			Insets: goey.DefaultInsets(),
-			Child: &goey.VBox{goey.MainCenter, goey.CrossCenter, []base.Widget{
+			Child: &goey.VBox{AlignMain: goey.MainCenter, AlignCross: goey.CrossCenter, Children: []base.Widget{
 				&goey.Label{Text: "Example Menu"},
 				&goey.Img{Image: gopher},
 			},
@@ -74,7 +74,7 @@ func renderSidebar() base.Widget {
 func renderMainbar() base.Widget {
 	return &goey.Expand{Child: &goey.Padding{
 		Insets: goey.DefaultInsets(),
-		Child: &goey.VBox{goey.MainCenter, goey.Stretch, []base.Widget{
+		Child: &goey.VBox{AlignMain: goey.MainCenter, AlignCross: goey.Stretch, Children: []base.Widget{
 			&Column{[]base.Widget{
 				&goey.Button{Text: "A1"}, &goey.Button{Text: "A2"}, &goey.Button{Text: "A3"}, &goey.Button{Text: "A4"},
 				&goey.Button{Text: "B1"}, &goey.Button{Text: "B2"}, &goey.Button{Text: "B3"}, &goey.Button{Text: "B4"},
@@ -16,10 +16,19 @@ var (
 // In an HBox or VBox, the widget will be positioned according to the rules
 // of its child.  However, any excess space along the main axis will be added
 // based on the ratio of the widget's factor to the sum of factors for all
-// widgets in the box.  Note that
+// widgets in the box.  If NoGrow is set, the widget does not receive any of
+// the excess space, which is useful for widgets that should only shrink.
+//
+// If the children of the box do not fit along the main axis, widgets with a
+// non-zero Shrink are reduced in size.  The overflow is divided according to
+// the ratio of the widget's shrink factor to the sum of shrink factors for all
+// widgets in the box, but no widget is reduced below its minimum intrinsic
+// size.
 type Expand struct {
 	Factor int         // Fraction (minus one) of available space used by this widget
 	Child  base.Widget // Child widget.
+	Shrink int         // Weight used to reduce this widget's size when the box overflows.  If zero, the widget is not reduced.
+	NoGrow bool        // If set, the widget does not grow to use excess space, and Factor is ignored.
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -33,7 +42,7 @@ func (*Expand) Kind() *base.Kind {
 func (w *Expand) Mount(parent base.Control) (base.Element, error) {
 	// Mount the child
 	child, err := base.Mount(parent, w.Child)
-	if err != nil {
+	if child == nil {
 		return nil, err
 	}
 
@@ -41,13 +50,29 @@ func (w *Expand) Mount(parent base.Control) (base.Element, error) {
 		parent: parent,
 		child:  child,
 		factor: w.Factor,
-	}, nil
+		shrink: w.Shrink,
+		noGrow: w.NoGrow,
+	}, err
 }
 
 type expandElement struct {
 	parent base.Control
 	child  base.Element
 	factor int
+	shrink int
+	noGrow bool
+	bounds base.Rectangle
+}
+
+func (w *expandElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *expandElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
 }
 
 func (w *expandElement) Close() {
@@ -62,7 +87,7 @@ func (*expandElement) Kind() *base.Kind {
 }
 
 func (w *expandElement) Layout(bc base.Constraints) base.Size {
-	return w.child.Layout(bc)
+	return base.Layout(w.child, bc)
 }
 
 func (w *expandElement) MinIntrinsicHeight(width base.Length) base.Length {
@@ -73,13 +98,25 @@ func (w *expandElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return w.child.MinIntrinsicWidth(height)
 }
 
+func (w *expandElement) Props() base.Widget {
+	return &Expand{
+		Factor: w.factor,
+		Child:  base.PropsOf(w.child),
+		Shrink: w.shrink,
+		NoGrow: w.noGrow,
+	}
+}
+
 func (w *expandElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	w.child.SetBounds(bounds)
 }
 
 func (w *expandElement) updateProps(data *Expand) (err error) {
 	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
 	w.factor = data.Factor
+	w.shrink = data.Shrink
+	w.noGrow = data.NoGrow
 	return err
 }
 
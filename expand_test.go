@@ -7,26 +7,6 @@ import (
 	"testing"
 )
 
-type Boundser interface {
-	Bounds() base.Rectangle
-}
-
-func (w *expandElement) Bounds() base.Rectangle {
-	return w.child.(Boundser).Bounds()
-}
-
-func (w *expandElement) Props() base.Widget {
-	child := base.Widget(nil)
-	if w.child != nil {
-		child = w.child.(Proper).Props()
-	}
-
-	return &Expand{
-		Factor: w.factor,
-		Child:  child,
-	}
-}
-
 func TestExpandMount(t *testing.T) {
 	testingMountWidgets(t,
 		&Expand{},
@@ -54,10 +34,12 @@ func TestExpandUpdateProps(t *testing.T) {
 		&Expand{},
 		&Expand{Child: &child},
 		&Expand{Child: &child, Factor: 1},
+		&Expand{Child: &child, Shrink: 1},
 	}, []base.Widget{
 		&Expand{Child: &child, Factor: 2},
 		&Expand{},
 		&Expand{Child: &child, Factor: 1},
+		&Expand{Child: &child, Shrink: 2, NoGrow: true},
 	})
 }
 
@@ -123,7 +105,7 @@ func TestExpandLayout(t *testing.T) {
 		}
 		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
 		for j, u := range v.bounds {
-			if got := v.children[j].(Boundser).Bounds(); got != u {
+			if got := v.children[j].Bounds(); got != u {
 				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
 			}
 		}
@@ -0,0 +1,287 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/animate"
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	expanderKind = base.NewKind("bitbucket.org/rj/goey.Expander")
+)
+
+// Expander describes a widget with a caption that the user can click to show
+// or hide its child.  A typical use is to hide advanced settings until they
+// are required.
+//
+// The child is placed below the caption when Expanded is true.  While
+// collapsed, the child remains mounted but hidden, so that any state in its
+// native controls is preserved.  OnChange is called whenever the user toggles
+// the expander, and the new state should be stored so that it is preserved
+// when the widget is updated.
+//
+// If Animate is set, the height of the expander changes smoothly when it is
+// toggled.  The child is hidden until the animation completes.
+type Expander struct {
+	Text     string      // Caption shown beside the toggle.
+	Expanded bool        // If true, the child is shown.
+	Animate  bool        // If true, changes in height will be animated.
+	Child    base.Widget // Child widget.
+
+	OnChange func(bool) // OnChange will be called whenever the user expands or collapses the widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Expander) Kind() *base.Kind {
+	return &expanderKind
+}
+
+// Mount creates an expander in the GUI.  The newly created widget will be a
+// child of the widget specified by parent.
+func (w *Expander) Mount(parent base.Control) (base.Element, error) {
+	retval := &expanderElement{
+		parent:   parent,
+		expanded: w.Expanded,
+		animate:  w.Animate,
+		onChange: w.OnChange,
+	}
+	retval.childParent = parent.WithValue(hiddenKey{}, retval)
+
+	err := retval.header.mount(parent, retval, w.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the parent's context is set to continue on error, the expander is
+	// mounted even if the child failed, provided that the child could still
+	// be created.
+	child, err := base.Mount(retval.childParent, w.Child)
+	if child == nil {
+		retval.header.close()
+		return nil, err
+	}
+	retval.child = child
+	retval.updateChildVisibility()
+
+	return retval, err
+}
+
+type expanderElement struct {
+	parent      base.Control
+	childParent base.Control // Parent for the child, with this element in the context.
+	header      expanderHeader
+	child       base.Element
+	expanded    bool
+	animate     bool
+	onChange    func(bool)
+	hidden      bool
+
+	// While animating, revealed is the height of the area below the header
+	// that is currently shown.
+	animating bool
+	revealed  base.Length
+	ease      animate.EaseLength
+
+	headerSize base.Size
+	childSize  base.Size
+	bounds     base.Rectangle
+}
+
+// AnimateFrame updates the height of the expander during an animation.
+func (w *expanderElement) AnimateFrame(time animate.Time) bool {
+	if !w.animating || w.child == nil {
+		return false
+	}
+
+	w.revealed = w.ease.Value(time)
+	if w.ease.Done(time) {
+		w.animating = false
+		w.updateChildVisibility()
+	}
+	base.InvalidateLayout()
+	requestLayout(w.parent)
+	return w.animating
+}
+
+func (w *expanderElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *expanderElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *expanderElement) Close() {
+	w.header.close()
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*expanderElement) Kind() *base.Kind {
+	return &expanderKind
+}
+
+// childVisible returns true if the child should be shown.
+func (w *expanderElement) childVisible() bool {
+	return w.expanded && !w.animating && !w.hidden
+}
+
+// isHidden returns true if the child is hidden, either because the expander
+// is collapsed, or because an ancestor has hidden its children.
+func (w *expanderElement) isHidden() bool {
+	return !w.childVisible() || contextHidden(w.parent)
+}
+
+// updateChildVisibility hides or shows the child's native controls to match
+// the state of the expander.
+func (w *expanderElement) updateChildVisibility() {
+	setHiddenElements(w.child, w.isHidden())
+}
+
+// revealedHeight returns the height of the area below the header that should
+// be shown, given the height required to show all of the child.
+func (w *expanderElement) revealedHeight(full base.Length) base.Length {
+	if w.animating {
+		return min(w.revealed, full)
+	}
+	if w.expanded {
+		return full
+	}
+	return 0
+}
+
+func (w *expanderElement) Layout(bc base.Constraints) base.Size {
+	w.headerSize = w.header.size()
+	if !w.expanded && !w.animating {
+		return bc.Constrain(w.headerSize)
+	}
+
+	// The child is measured, even while animating, so that the height of the
+	// expander can be limited to the height of the child.
+	w.childSize = base.Layout(w.child, bc.Inset(0, w.headerSize.Height+labelGap))
+	return bc.Constrain(base.Size{
+		max(w.headerSize.Width, w.childSize.Width),
+		w.headerSize.Height + w.revealedHeight(labelGap+w.childSize.Height),
+	})
+}
+
+func (w *expanderElement) MinIntrinsicHeight(width base.Length) base.Length {
+	header := w.header.size().Height
+	if !w.expanded && !w.animating {
+		return header
+	}
+	return header + w.revealedHeight(labelGap+w.child.MinIntrinsicHeight(width))
+}
+
+func (w *expanderElement) MinIntrinsicWidth(height base.Length) base.Length {
+	header := w.header.size()
+	if !w.expanded && !w.animating {
+		return header.Width
+	}
+	height = guardInf(height, max(0, height-header.Height-labelGap))
+	return max(header.Width, w.child.MinIntrinsicWidth(height))
+}
+
+func (w *expanderElement) Props() base.Widget {
+	return &Expander{
+		Text:     w.header.text(),
+		Expanded: w.expanded,
+		Animate:  w.animate,
+		Child:    base.PropsOf(w.child),
+		OnChange: w.onChange,
+	}
+}
+
+func (w *expanderElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	if w.headerSize == (base.Size{}) {
+		// Layout has not been performed, so the size of the header is not
+		// known.
+		w.headerSize = w.header.size()
+	}
+
+	// The header fills the width of the expander.
+	top := bounds.Min.Y + w.headerSize.Height
+	w.header.setBounds(base.Rect(bounds.Min.X, bounds.Min.Y, bounds.Max.X, top))
+
+	// The child is only positioned while it is visible.
+	if w.childVisible() {
+		top += labelGap
+		w.child.SetBounds(mirrorForDirection(w.parent, bounds, base.Rect(
+			bounds.Min.X, top, bounds.Min.X+w.childSize.Width, top+w.childSize.Height,
+		)))
+	}
+}
+
+// setHidden hides or shows the header and the child.  While the expander is
+// collapsed, the child remains hidden.
+func (w *expanderElement) setHidden(value bool) {
+	w.hidden = value
+	w.header.setHidden(value)
+	w.updateChildVisibility()
+}
+
+// setExpanded changes the state of the expander, and starts an animation if
+// required.
+func (w *expanderElement) setExpanded(value bool) {
+	if w.expanded == value {
+		return
+	}
+
+	w.animating = false
+	if w.animate {
+		// The animation starts from the height currently shown.
+		current := w.bounds.Dy() - w.headerSize.Height
+		target := base.Length(0)
+		if value {
+			target = labelGap + base.Layout(w.child, base.TightWidth(w.bounds.Dx())).Height
+		}
+		w.revealed = max(0, current)
+		w.ease = animate.NewEaseLength(target, w.revealed)
+		w.animating = true
+		animate.AddAnimation(w)
+	}
+
+	// The state is updated before the header, so that any notification from
+	// the header is ignored.
+	w.expanded = value
+	w.header.setExpanded(value)
+	w.updateChildVisibility()
+}
+
+// toggle is called by the header when the user expands or collapses the
+// expander.
+func (w *expanderElement) toggle() {
+	w.setExpanded(!w.expanded)
+	if w.onChange != nil {
+		w.onChange(w.expanded)
+	}
+
+	// The size of the expander has changed.
+	base.InvalidateLayout()
+	requestLayout(w.parent)
+}
+
+func (w *expanderElement) updateProps(data *Expander) (err error) {
+	if err := w.header.setText(data.Text); err != nil {
+		return err
+	}
+	w.animate = data.Animate
+	w.onChange = data.OnChange
+	w.setExpanded(data.Expanded)
+
+	w.child, err = base.DiffChild(w.childParent, w.child, data.Child)
+	// Any controls created by the update will be visible, so the child is
+	// hidden again if required.
+	w.updateChildVisibility()
+	return err
+}
+
+func (w *expanderElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Expander))
+}
@@ -0,0 +1,51 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type expanderHeader struct {
+	Control
+	caption string
+}
+
+func (d *expanderHeader) mount(parent base.Control, owner *expanderElement, text string) error {
+	d.caption = text
+	d.canFocus = true
+	return nil
+}
+
+func (d *expanderHeader) close() {
+	d.Control.Close()
+}
+
+func (d *expanderHeader) setBounds(bounds base.Rectangle) {
+	d.Control.SetBounds(bounds)
+}
+
+func (d *expanderHeader) setExpanded(value bool) {
+	// Nothing required when headless.
+}
+
+func (d *expanderHeader) setText(text string) error {
+	d.caption = text
+	return nil
+}
+
+func (d *expanderHeader) size() base.Size {
+	// Space for an arrow, followed by the caption.
+	caption := measureText(d.caption)
+	return base.Size{13*DIP + labelGap + caption.Width, max(13*DIP, caption.Height)}
+}
+
+func (d *expanderHeader) text() string {
+	return d.caption
+}
+
+// Click toggles the expander, as if the user had clicked on the header.
+func (w *expanderElement) Click() {
+	w.toggle()
+}
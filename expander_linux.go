@@ -0,0 +1,84 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/internal/syscall"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+type expanderHeader struct {
+	handle *gtk.Expander
+	owner  *expanderElement
+}
+
+func (d *expanderHeader) mount(parent base.Control, owner *expanderElement, text string) error {
+	// The expander does not contain the child, which is instead placed below
+	// the expander as a sibling.  Only the header of the expander is shown.
+	control, err := gtk.ExpanderNew(text)
+	if err != nil {
+		return err
+	}
+	control.SetExpanded(owner.expanded)
+	parent.Handle.Add(control)
+
+	d.handle = control
+	d.owner = owner
+
+	control.Connect("destroy", expanderHeaderOnDestroy, d)
+	// The expander changes its state in the default handler, so the new state
+	// is only available after.
+	control.ConnectAfter("activate", expanderHeaderOnActivate, d)
+	control.Show()
+
+	return nil
+}
+
+func expanderHeaderOnDestroy(widget *gtk.Expander, d *expanderHeader) {
+	d.handle = nil
+}
+
+func expanderHeaderOnActivate(widget *gtk.Expander, d *expanderHeader) {
+	if widget.GetExpanded() != d.owner.expanded {
+		d.owner.toggle()
+	}
+}
+
+func (d *expanderHeader) close() {
+	if d.handle != nil {
+		d.handle.Destroy()
+		d.handle = nil
+	}
+}
+
+func (d *expanderHeader) setBounds(bounds base.Rectangle) {
+	pixels := bounds.Pixels()
+	syscall.SetBounds(&d.handle.Widget, pixels.Min.X, pixels.Min.Y, pixels.Dx(), pixels.Dy())
+}
+
+func (d *expanderHeader) setExpanded(value bool) {
+	if d.handle.GetExpanded() != value {
+		d.handle.SetExpanded(value)
+	}
+}
+
+func (d *expanderHeader) setHidden(value bool) {
+	d.handle.SetVisible(!value)
+}
+
+func (d *expanderHeader) setText(text string) error {
+	d.handle.SetLabel(text)
+	return nil
+}
+
+func (d *expanderHeader) size() base.Size {
+	_, width := d.handle.GetPreferredWidth()
+	_, height := d.handle.GetPreferredHeight()
+	return base.Size{base.FromPixelsX(width), base.FromPixelsY(height)}
+}
+
+func (d *expanderHeader) text() string {
+	return d.handle.GetLabel()
+}
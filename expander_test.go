@@ -0,0 +1,36 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+func TestExpanderMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Expander{Text: "A", Child: &Button{Text: "A"}},
+		&Expander{Text: "B", Expanded: true, Child: &VBox{Children: []base.Widget{
+			&Checkbox{Text: "C1"}, &Checkbox{Text: "C2"},
+		}}},
+		&Expander{Text: "C", Expanded: true, Animate: true, Child: &Label{Text: "C"}},
+	)
+}
+
+func TestExpanderClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Expander{Text: "A", Child: &Button{Text: "A"}},
+		&Expander{Text: "B", Expanded: true, Child: &Label{Text: "B"}},
+	)
+}
+
+func TestExpanderUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Expander{Text: "A", Child: &Button{Text: "A"}},
+		&Expander{Text: "B", Expanded: true, Child: &Label{Text: "B"}},
+		&Expander{Text: "C", Expanded: true, Child: &Button{Text: "C"}},
+	}, []base.Widget{
+		&Expander{Text: "AB", Expanded: true, Child: &Button{Text: "AB"}},
+		&Expander{Text: "BC", Child: &Label{Text: "BC"}},
+		&Expander{Text: "C", Expanded: true, Child: &Label{Text: "C"}},
+	})
+}
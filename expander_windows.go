@@ -0,0 +1,133 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"syscall"
+	"unsafe"
+
+	"bitbucket.org/rj/goey/base"
+	"github.com/lxn/win"
+)
+
+// expanderCaption adds an arrow before the text, to indicate whether the
+// expander is expanded or collapsed.
+func expanderCaption(text string, expanded bool) string {
+	if expanded {
+		return "▼ " + text
+	}
+	return "► " + text
+}
+
+type expanderHeader struct {
+	Control
+	owner   *expanderElement
+	caption string
+}
+
+func (d *expanderHeader) mount(parent base.Control, owner *expanderElement, text string) error {
+	// There is no native expander, so a push-like check box is used for the
+	// header.  The check state shows whether the expander is expanded.
+	const STYLE = win.WS_CHILD | win.WS_VISIBLE | win.WS_TABSTOP | win.BS_AUTOCHECKBOX | win.BS_PUSHLIKE | win.BS_LEFT
+	hwnd, _, err := createControlWindow(0, &button.className[0], expanderCaption(text, owner.expanded), STYLE, parent.HWnd)
+	if err != nil {
+		return err
+	}
+	if owner.expanded {
+		win.SendMessage(hwnd, win.BM_SETCHECK, win.BST_CHECKED, 0)
+	}
+
+	d.hWnd = hwnd
+	d.owner = owner
+	d.caption = text
+
+	// Subclass the window procedure
+	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(d)))
+	subclassWindowProcedure(hwnd, &button.oldWindowProc, expanderHeaderWindowProc)
+	return nil
+}
+
+func (d *expanderHeader) close() {
+	d.Control.Close()
+}
+
+func (d *expanderHeader) setBounds(bounds base.Rectangle) {
+	d.Control.SetBounds(bounds)
+}
+
+func (d *expanderHeader) setExpanded(value bool) {
+	if value {
+		win.SendMessage(d.hWnd, win.BM_SETCHECK, win.BST_CHECKED, 0)
+	} else {
+		win.SendMessage(d.hWnd, win.BM_SETCHECK, win.BST_UNCHECKED, 0)
+	}
+	d.SetText(expanderCaption(d.caption, value))
+}
+
+func (d *expanderHeader) setText(text string) error {
+	d.caption = text
+	return d.SetText(expanderCaption(text, d.owner.expanded))
+}
+
+func (d *expanderHeader) size() base.Size {
+	text, err := syscall.UTF16FromString(expanderCaption(d.caption, d.owner.expanded))
+	if err != nil {
+		return base.Size{}
+	}
+
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	width, _ := d.CalcRect(text)
+	return base.Size{base.FromPixelsX(int(width) + 7), 23 * DIP}
+}
+
+func (d *expanderHeader) text() string {
+	return d.caption
+}
+
+func (w *expanderElement) SetOrder(previous win.HWND) win.HWND {
+	previous = w.header.SetOrder(previous)
+	if w.child != nil {
+		previous = w.child.SetOrder(previous)
+	}
+	return previous
+}
+
+func expanderHeaderWindowProc(hwnd win.HWND, msg uint32, wParam uintptr, lParam uintptr) (result uintptr) {
+	switch msg {
+	case win.WM_DESTROY:
+		// Make sure that the data structure on the Go-side does not point to a non-existent
+		// window.
+		expanderHeaderGetPtr(hwnd).hWnd = 0
+		// Defer to the old window proc
+
+	case win.WM_COMMAND:
+		// WM_COMMAND is sent to the parent, which will only forward certain
+		// message.  This code should only ever see BN_CLICKED, but we will
+		// still check.
+		switch notification := win.HIWORD(uint32(wParam)); notification {
+		case win.BN_CLICKED:
+			d := expanderHeaderGetPtr(hwnd)
+			if checked := win.SendMessage(hwnd, win.BM_GETCHECK, 0, 0) == win.BST_CHECKED; checked != d.owner.expanded {
+				d.owner.toggle()
+			}
+		}
+		return 0
+	}
+
+	return win.CallWindowProc(button.oldWindowProc, hwnd, msg, wParam, lParam)
+}
+
+func expanderHeaderGetPtr(hwnd win.HWND) *expanderHeader {
+	gwl := win.GetWindowLongPtr(hwnd, win.GWLP_USERDATA)
+	if gwl == 0 {
+		panic("Internal error.")
+	}
+
+	ptr := (*expanderHeader)(unsafe.Pointer(gwl))
+	if ptr.hWnd != hwnd && ptr.hWnd != 0 {
+		panic("Internal error.")
+	}
+
+	return ptr
+}
@@ -0,0 +1,395 @@
+package goey
+
+import (
+	"strings"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	formKind = base.NewKind("bitbucket.org/rj/goey.Form")
+)
+
+// FormRow describes a single row in a form.
+type FormRow struct {
+	Label string      // Text for the label.  An underscore marks the next character as the mnemonic.
+	Field base.Widget // Widget used to edit the field.
+	Help  string      // Optional help text, or error message, shown below the field.
+}
+
+// Form describes a layout widget that arranges fields in rows, with a label
+// beside each field.  The labels are aligned in a column, and the fields in a
+// second column that fills the remaining width.
+//
+// Each label is associated with its field, so that the label's mnemonic will
+// move focus to the field.  To use a literal underscore in a label, use two
+// underscores.  Rows without a label still place the field in the second
+// column, which is useful for checkboxes.
+//
+// Labels are aligned with the baseline of their field, if the field displays
+// text.  The spacing between labels, fields, help text, and rows follows the
+// same rules as for VBox.
+type Form struct {
+	Rows []FormRow // Rows of the form, from top to bottom.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Form) Kind() *base.Kind {
+	return &formKind
+}
+
+// Mount creates a form layout for child widgets in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *Form) Mount(parent base.Control) (base.Element, error) {
+	// Mount all of the fields.  If the parent's context is set to continue on
+	// error, the form is mounted even if some of the fields failed.
+	fields, err := base.DiffChildren(parent, nil, formFieldWidgets(w.Rows))
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
+	}
+
+	retval := &formElement{
+		parent: parent,
+		fields: fields,
+	}
+	if err2 := retval.updateRows(w.Rows); err2 != nil {
+		retval.Close()
+		return nil, err2
+	}
+	return retval, err
+}
+
+func formFieldWidgets(rows []FormRow) []base.Widget {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	widgets := make([]base.Widget, 0, len(rows))
+	for _, v := range rows {
+		widgets = append(widgets, v.Field)
+	}
+	return widgets
+}
+
+// formatMnemonic converts a label, where underscores mark mnemonics, to use
+// a different prefix.  Double underscores are converted to a literal
+// underscore, and any use of the new prefix in the text is doubled.
+func formatMnemonic(text string, prefix string) string {
+	buf := strings.Builder{}
+	for i := 0; i < len(text); i++ {
+		switch {
+		case strings.HasPrefix(text[i:], "__"):
+			buf.WriteByte('_')
+			i++
+		case text[i] == '_':
+			buf.WriteString(prefix)
+		case prefix != "" && strings.HasPrefix(text[i:], prefix):
+			buf.WriteString(prefix)
+			buf.WriteString(prefix)
+			i += len(prefix) - 1
+		default:
+			buf.WriteByte(text[i])
+		}
+	}
+	return buf.String()
+}
+
+type formRowInfo struct {
+	props FormRow // Properties as provided, but without the field.
+	label *labelElement
+	help  base.Element
+
+	// Layout of the row, relative to the top of the row.
+	labelSize base.Size
+	fieldSize base.Size
+	helpSize  base.Size
+	labelTop  base.Length
+	fieldTop  base.Length
+	helpTop   base.Length
+	height    base.Length
+}
+
+type formElement struct {
+	parent base.Control
+	fields []base.Element
+	rows   []formRowInfo
+
+	labelWidth base.Length
+	fieldWidth base.Length
+	bounds     base.Rectangle
+}
+
+func (w *formElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *formElement) Children() []base.Element {
+	children := make([]base.Element, 0, 3*len(w.rows))
+	for i, v := range w.rows {
+		if v.label != nil {
+			children = append(children, v.label)
+		}
+		children = append(children, w.fields[i])
+		if v.help != nil {
+			children = append(children, v.help)
+		}
+	}
+	return children
+}
+
+func (w *formElement) Close() {
+	for _, v := range w.rows {
+		if v.label != nil {
+			v.label.Close()
+		}
+		if v.help != nil {
+			v.help.Close()
+		}
+	}
+	w.rows = nil
+	base.CloseElements(w.fields)
+	w.fields = nil
+}
+
+func (*formElement) Kind() *base.Kind {
+	return &formKind
+}
+
+// columnGap returns the space between the column of labels and the column of
+// fields.
+func (w *formElement) columnGap() base.Length {
+	for _, v := range w.rows {
+		if v.label != nil {
+			return labelGap
+		}
+	}
+	// If there are no labels, there is no need for a gap.
+	return 0
+}
+
+func (w *formElement) measureLabels() base.Length {
+	width := base.Length(0)
+	for _, v := range w.rows {
+		if v.label != nil {
+			width = max(width, v.label.MinIntrinsicWidth(base.Inf))
+		}
+	}
+	return width
+}
+
+func (w *formElement) Layout(bc base.Constraints) base.Size {
+	if len(w.rows) == 0 {
+		return bc.Constrain(base.Size{})
+	}
+
+	// Determine the widths of the two columns.  The fields fill the available
+	// width, or otherwise take their natural width.
+	w.labelWidth = w.measureLabels()
+	gap := w.columnGap()
+	if bc.HasBoundedWidth() {
+		w.fieldWidth = max(0, bc.Max.Width-w.labelWidth-gap)
+	} else {
+		w.fieldWidth = 0
+		for i, v := range w.rows {
+			w.fieldWidth = max(w.fieldWidth, base.Layout(w.fields[i], base.Loose(base.Size{base.Inf, base.Inf})).Width)
+			if v.help != nil {
+				w.fieldWidth = max(w.fieldWidth, v.help.MinIntrinsicWidth(base.Inf))
+			}
+		}
+		w.fieldWidth = max(0, bc.ConstrainWidth(w.labelWidth+gap+w.fieldWidth)-w.labelWidth-gap)
+	}
+
+	height := calculateVGap(nil, nil).Scale(len(w.rows)-1, 1)
+	for i := range w.rows {
+		height += w.layoutRow(&w.rows[i], w.fields[i])
+	}
+	return bc.Constrain(base.Size{w.labelWidth + gap + w.fieldWidth, height})
+}
+
+// layoutRow determines the positions of the label, field, and help text
+// within a row, and returns the height of the row.
+func (w *formElement) layoutRow(row *formRowInfo, field base.Element) base.Length {
+	row.fieldSize = base.Layout(field, base.TightWidth(w.fieldWidth))
+	row.labelSize, row.labelTop, row.fieldTop = base.Size{}, 0, 0
+	if row.label != nil {
+		row.labelSize = base.Layout(row.label, base.Loose(base.Size{w.labelWidth, base.Inf}))
+
+		// Align the text of the label with the text of the field.  If the
+		// field does not display text, the label is aligned with its top.
+		if fieldBaseline, ok := base.BaselineOf(field, row.fieldSize.Height); ok {
+			labelBaseline := childBaseline(row.label, row.labelSize.Height)
+			ascent := max(fieldBaseline, labelBaseline)
+			row.labelTop = ascent - labelBaseline
+			row.fieldTop = ascent - fieldBaseline
+		}
+	}
+	row.height = max(row.labelTop+row.labelSize.Height, row.fieldTop+row.fieldSize.Height)
+
+	row.helpSize, row.helpTop = base.Size{}, 0
+	if row.help != nil {
+		row.helpSize = base.Layout(row.help, base.Loose(base.Size{w.fieldWidth, base.Inf}))
+		row.helpTop = row.fieldTop + row.fieldSize.Height + labelGap
+		row.height = max(row.height, row.helpTop+row.helpSize.Height)
+	}
+	return row.height
+}
+
+func (w *formElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if len(w.rows) == 0 {
+		return 0
+	}
+
+	fieldWidth := guardInf(width, max(0, width-w.measureLabels()-w.columnGap()))
+	height := calculateVGap(nil, nil).Scale(len(w.rows)-1, 1)
+	for i, v := range w.rows {
+		rowHeight := w.fields[i].MinIntrinsicHeight(fieldWidth)
+		if v.label != nil {
+			rowHeight = max(rowHeight, v.label.MinIntrinsicHeight(base.Inf))
+		}
+		if v.help != nil {
+			rowHeight += labelGap + v.help.MinIntrinsicHeight(fieldWidth)
+		}
+		height += rowHeight
+	}
+	return height
+}
+
+func (w *formElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if len(w.rows) == 0 {
+		return 0
+	}
+
+	fieldWidth := base.Length(0)
+	for i, v := range w.rows {
+		fieldWidth = max(fieldWidth, w.fields[i].MinIntrinsicWidth(base.Inf))
+		if v.help != nil {
+			fieldWidth = max(fieldWidth, v.help.MinIntrinsicWidth(base.Inf))
+		}
+	}
+	return w.measureLabels() + w.columnGap() + fieldWidth
+}
+
+func (w *formElement) Props() base.Widget {
+	rows := []FormRow(nil)
+	if len(w.rows) != 0 {
+		rows = make([]FormRow, 0, len(w.rows))
+		for i, v := range w.rows {
+			row := v.props
+			row.Field = base.PropsOf(w.fields[i])
+			rows = append(rows, row)
+		}
+	}
+
+	return &Form{
+		Rows: rows,
+	}
+}
+
+func (w *formElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+
+	fieldX := bounds.Min.X + w.labelWidth + w.columnGap()
+	posY := bounds.Min.Y
+	for i, v := range w.rows {
+		if v.label != nil {
+			w.setChildBounds(v.label, bounds.Min.X, posY+v.labelTop, v.labelSize)
+		}
+		w.setChildBounds(w.fields[i], fieldX, posY+v.fieldTop, v.fieldSize)
+		if v.help != nil {
+			w.setChildBounds(v.help, fieldX, posY+v.helpTop, v.helpSize)
+		}
+		posY += v.height + calculateVGap(nil, nil)
+	}
+}
+
+func (w *formElement) setChildBounds(child base.Element, x, y base.Length, size base.Size) {
+	child.SetBounds(mirrorForDirection(w.parent, w.bounds, base.Rectangle{
+		base.Point{x, y},
+		base.Point{x + size.Width, y + size.Height},
+	}))
+}
+
+// updateRows updates the labels and help text for each row, and associates
+// the labels with the fields.  Rows are only kept for fields that were
+// mounted.
+func (w *formElement) updateRows(rows []FormRow) error {
+	count := len(w.fields)
+	if len(rows) < count {
+		count = len(rows)
+	}
+
+	// Close the labels and help text for any rows that have been removed.
+	previous := len(w.rows)
+	for i := count; i < previous; i++ {
+		if v := w.rows[i].label; v != nil {
+			v.Close()
+		}
+		if v := w.rows[i].help; v != nil {
+			v.Close()
+		}
+	}
+	if count <= previous {
+		w.rows = w.rows[:count]
+	} else {
+		w.rows = append(w.rows, make([]formRowInfo, count-previous)...)
+	}
+
+	for i := range w.rows {
+		row := &w.rows[i]
+		if err := w.updateRow(row, rows[i]); err != nil {
+			return err
+		}
+		row.props = rows[i]
+		row.props.Field = nil
+		if row.label != nil {
+			row.label.setMnemonicField(w.fields[i])
+		}
+	}
+	return nil
+}
+
+func (w *formElement) updateRow(row *formRowInfo, data FormRow) (err error) {
+	switch {
+	case data.Label == "":
+		if row.label != nil {
+			row.label.Close()
+			row.label = nil
+		}
+	case row.label == nil:
+		row.label, err = mountFormLabel(w.parent, data.Label)
+		if err != nil {
+			return err
+		}
+	case row.props.Label != data.Label:
+		if err := row.label.setFormText(data.Label); err != nil {
+			return err
+		}
+	}
+
+	if data.Help == "" {
+		if row.help != nil {
+			row.help.Close()
+			row.help = nil
+		}
+		return nil
+	}
+	help, err := base.DiffChild(w.parent, row.help, &Label{Text: data.Help})
+	if help == nil {
+		return err
+	}
+	row.help = help
+	return err
+}
+
+func (w *formElement) updateProps(data *Form) (err error) {
+	w.fields, err = base.DiffChildren(w.parent, w.fields, formFieldWidgets(data.Rows))
+	if err2 := w.updateRows(data.Rows); err2 != nil && err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (w *formElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Form))
+}
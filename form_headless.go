@@ -0,0 +1,21 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+func mountFormLabel(parent base.Control, text string) (*labelElement, error) {
+	return &labelElement{text: formatMnemonic(text, "")}, nil
+}
+
+func (w *labelElement) setFormText(text string) error {
+	w.text = formatMnemonic(text, "")
+	return nil
+}
+
+func (w *labelElement) setMnemonicField(field base.Element) {
+	w.mnemonicField = field
+}
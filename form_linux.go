@@ -0,0 +1,51 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func mountFormLabel(parent base.Control, text string) (*labelElement, error) {
+	// GTK uses underscores to mark mnemonics, so the text can be used as is.
+	handle, err := gtk.LabelNewWithMnemonic(text)
+	if err != nil {
+		return nil, err
+	}
+	parent.Handle.Add(handle)
+	handle.SetJustify(gtk.JUSTIFY_LEFT)
+	handle.SetHAlign(gtk.ALIGN_START)
+	handle.SetLineWrap(false)
+	handle.Show()
+
+	retval := &labelElement{Control: Control{handle: &handle.Widget}}
+	handle.Connect("destroy", labelOnDestroy, retval)
+
+	return retval, nil
+}
+
+func (w *labelElement) setFormText(text string) error {
+	w.label().SetTextWithMnemonic(text)
+	return nil
+}
+
+// setMnemonicField associates the label with the first native control of
+// the field.
+func (w *labelElement) setMnemonicField(field base.Element) {
+	found := false
+	base.Walk(field, func(elem base.Element) bool {
+		if found {
+			return false
+		}
+		handle, ok := elem.(interface{ Handle() *gtk.Widget })
+		if !ok {
+			return true
+		}
+
+		w.label().SetMnemonicWidget(handle.Handle())
+		found = true
+		return false
+	})
+}
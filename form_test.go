@@ -0,0 +1,101 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func testingFormRows() []FormRow {
+	return []FormRow{
+		{Label: "_Name", Field: &TextInput{Value: "A"}},
+		{Label: "_Email", Field: &TextInput{Value: "B"}, Help: "Used to reset your password."},
+		{Field: &Checkbox{Text: "Subscribe"}},
+	}
+}
+
+func TestFormMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Form{},
+		&Form{Rows: testingFormRows()},
+		&Form{Rows: []FormRow{
+			{Label: "Label", Field: &Button{Text: "A"}, Help: "Error"},
+		}},
+	)
+}
+
+func TestFormClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Form{},
+		&Form{Rows: testingFormRows()},
+	)
+}
+
+func TestFormUpdateProps(t *testing.T) {
+	changed := []FormRow{
+		{Label: "_Email", Field: &TextInput{Value: "B"}},
+		{Label: "_Name", Field: &TextInput{Value: "A"}, Help: "Required."},
+	}
+
+	testingUpdateWidgets(t, []base.Widget{
+		&Form{},
+		&Form{Rows: testingFormRows()},
+		&Form{Rows: changed},
+	}, []base.Widget{
+		&Form{Rows: testingFormRows()},
+		&Form{Rows: changed},
+		&Form{},
+	})
+}
+
+func TestFormatMnemonic(t *testing.T) {
+	cases := []struct {
+		in     string
+		prefix string
+		out    string
+	}{
+		{"Name", "&", "Name"},
+		{"_Name", "&", "&Name"},
+		{"Save _As", "&", "Save &As"},
+		{"snake__case", "&", "snake_case"},
+		{"Fish && _Chips", "&", "Fish &&&& &Chips"},
+		{"Fish & _Chips", "&", "Fish && &Chips"},
+		{"_Name", "", "Name"},
+		{"snake__case", "", "snake_case"},
+	}
+
+	for i, v := range cases {
+		if out := formatMnemonic(v.in, v.prefix); out != v.out {
+			t.Errorf("Case %d: Incorrect text, got %q, want %q", i, out, v.out)
+		}
+	}
+}
+
+func TestFormLayout(t *testing.T) {
+	// Rows without labels place the fields in a single column.
+	fields := mock.NewList(
+		base.Size{20 * DIP, 10 * DIP},
+		base.Size{30 * DIP, 15 * DIP},
+	)
+	in := &formElement{fields: fields}
+	if err := in.updateRows([]FormRow{{}, {Help: ""}}); err != nil {
+		t.Fatalf("Failed to update rows, %s", err)
+	}
+
+	size := in.Layout(base.Loose(base.Size{100 * DIP, 100 * DIP}))
+	if want := (base.Size{100 * DIP, 36 * DIP}); size != want {
+		t.Errorf("Incorrect size, got %s, want %s", size, want)
+	}
+	in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+	if got, want := fields[1].Bounds(), base.Rect(0, 21*DIP, 100*DIP, 36*DIP); got != want {
+		t.Errorf("Incorrect bounds, got %s, want %s", got, want)
+	}
+
+	if value := in.MinIntrinsicWidth(base.Inf); value != 30*DIP {
+		t.Errorf("Incorrect min intrinsic width, got %s, want %s", value, 30*DIP)
+	}
+	if value := in.MinIntrinsicHeight(base.Inf); value != 36*DIP {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, 36*DIP)
+	}
+}
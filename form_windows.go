@@ -0,0 +1,41 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"github.com/lxn/win"
+)
+
+func mountFormLabel(parent base.Control, text string) (*labelElement, error) {
+	// Static controls use ampersands to mark mnemonics.
+	elem, err := (&Label{Text: formatMnemonic(text, "&")}).mount(parent)
+	if err != nil {
+		return nil, err
+	}
+	return elem.(*labelElement), nil
+}
+
+func (w *labelElement) setFormText(text string) error {
+	return w.updateProps(&Label{Text: formatMnemonic(text, "&")})
+}
+
+func (w *labelElement) setMnemonicField(field base.Element) {
+	// When a static control's mnemonic is pressed, the dialog manager moves
+	// focus to the next control in the tab order.  SetOrder places the label
+	// immediately before its field.
+}
+
+func (w *formElement) SetOrder(previous win.HWND) win.HWND {
+	for i, v := range w.rows {
+		if v.label != nil {
+			previous = v.label.SetOrder(previous)
+		}
+		previous = w.fields[i].SetOrder(previous)
+		if v.help != nil {
+			previous = v.help.SetOrder(previous)
+		}
+	}
+	return previous
+}
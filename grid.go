@@ -0,0 +1,537 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	gridKind = base.NewKind("bitbucket.org/rj/goey.Grid")
+)
+
+// TrackSizing identifies how the size of a row or column in a grid is
+// determined.
+type TrackSizing uint8
+
+// Allowed values for the sizing of a row or column in a grid.
+const (
+	TrackAuto  TrackSizing = iota // Track is sized to fit the minimum size of its children.
+	TrackFixed                    // Track has the size given in the track definition.
+	TrackFlex                     // Track fits its children, and then shares any extra space with other flexible tracks.
+)
+
+// GridTrack describes the size of a row or column in a grid.
+type GridTrack struct {
+	Sizing TrackSizing // Method used to determine the size of the track.
+	Size   base.Length // Size of the track, when the sizing is TrackFixed.
+	Flex   int         // Share of the extra space, when the sizing is TrackFlex.  Values less than one are treated as one.
+}
+
+// GridCell describes the position of a child widget in a grid.
+type GridCell struct {
+	Row        int            // Index of the first row occupied by the child.
+	Column     int            // Index of the first column occupied by the child.
+	RowSpan    int            // Number of rows occupied by the child.  Values less than one are treated as one.
+	ColumnSpan int            // Number of columns occupied by the child.  Values less than one are treated as one.
+	HAlign     CrossAxisAlign // Horizontal alignment of the child within its cell.
+	VAlign     CrossAxisAlign // Vertical alignment of the child within its cell.
+	Child      base.Widget    // Child widget.
+}
+
+// Grid describes a layout widget that arranges its child widgets into rows
+// and columns.  Unlike nested HBox and VBox widgets, the columns are shared
+// by all of the rows, so that children are aligned in both directions.
+//
+// The rows and columns are described by Rows and Columns.  If a child is
+// placed outside of the tracks that have been described, additional tracks
+// are added with the sizing TrackAuto.  Children may span several rows or
+// columns, in which case the spanned tracks, except those with fixed sizes,
+// will grow as necessary to fit the child.
+//
+// The children are aligned within their cells using HAlign and VAlign.  The
+// default, Stretch, fills the cell.  Baselines are not supported, and so
+// Baseline is treated as CrossStart.
+//
+// The gaps between the columns and between the rows are set by ColumnGap and
+// RowGap.  If zero, the gap is the default spacing between unrelated
+// controls.
+type Grid struct {
+	Columns   []GridTrack // Sizing for the columns, from left to right.
+	Rows      []GridTrack // Sizing for the rows, from top to bottom.
+	ColumnGap base.Length // Space between adjacent columns.
+	RowGap    base.Length // Space between adjacent rows.
+	Children  []GridCell  // Children, and their position in the grid.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Grid) Kind() *base.Kind {
+	return &gridKind
+}
+
+// Mount creates a grid layout for child widgets in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *Grid) Mount(parent base.Control) (base.Element, error) {
+	// Mount all of the children.  If the parent's context is set to continue
+	// on error, the grid is mounted even if some of the children failed.
+	c, err := base.DiffChildren(parent, nil, gridChildWidgets(w.Children))
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
+	}
+
+	return &gridElement{
+		parent:    parent,
+		children:  c,
+		cells:     updateGridCells(c, w.Children, nil),
+		columns:   w.Columns,
+		rows:      w.Rows,
+		columnGap: w.ColumnGap,
+		rowGap:    w.RowGap,
+	}, err
+}
+
+func gridChildWidgets(cells []GridCell) []base.Widget {
+	if len(cells) == 0 {
+		return nil
+	}
+
+	widgets := make([]base.Widget, 0, len(cells))
+	for _, v := range cells {
+		widgets = append(widgets, v.Child)
+	}
+	return widgets
+}
+
+// gridSpan is the range of tracks occupied by a cell along one axis.
+type gridSpan struct {
+	start, count int
+}
+
+func makeGridSpan(start, count int) gridSpan {
+	if start < 0 {
+		start = 0
+	}
+	if count < 1 {
+		count = 1
+	}
+	return gridSpan{start, count}
+}
+
+func (s gridSpan) end() int {
+	return s.start + s.count
+}
+
+type gridCellInfo struct {
+	props          GridCell // Properties as provided, but without the child.
+	column, row    gridSpan
+	hAlign, vAlign CrossAxisAlign
+	size           base.Size
+}
+
+func updateGridCells(children []base.Element, cells []GridCell, info []gridCellInfo) []gridCellInfo {
+	if len(children) <= cap(info) {
+		info = info[:len(children)]
+	} else {
+		info = make([]gridCellInfo, len(children))
+	}
+
+	for i := range info {
+		// If the reconciliation stopped after an error, there may be more
+		// children than cells.  The extra children are placed using the
+		// default cell.
+		v := &GridCell{}
+		if i < len(cells) {
+			v = &cells[i]
+		}
+		info[i] = gridCellInfo{
+			props: GridCell{
+				Row:        v.Row,
+				Column:     v.Column,
+				RowSpan:    v.RowSpan,
+				ColumnSpan: v.ColumnSpan,
+				HAlign:     v.HAlign,
+				VAlign:     v.VAlign,
+			},
+			column: makeGridSpan(v.Column, v.ColumnSpan),
+			row:    makeGridSpan(v.Row, v.RowSpan),
+			hAlign: v.HAlign,
+			vAlign: v.VAlign,
+		}
+	}
+	return info
+}
+
+type gridElement struct {
+	parent    base.Control
+	children  []base.Element
+	cells     []gridCellInfo
+	columns   []GridTrack
+	rows      []GridTrack
+	columnGap base.Length
+	rowGap    base.Length
+
+	columnWidths []base.Length
+	rowHeights   []base.Length
+	bounds       base.Rectangle
+	cache        base.LayoutCache
+}
+
+func (w *gridElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *gridElement) Children() []base.Element {
+	return w.children
+}
+
+func (w *gridElement) Close() {
+	base.CloseElements(w.children)
+	w.children = nil
+	w.cells = nil
+}
+
+func (*gridElement) Kind() *base.Kind {
+	return &gridKind
+}
+
+func (w *gridElement) hgap() base.Length {
+	if w.columnGap != 0 {
+		return w.columnGap
+	}
+	return calculateHGap(nil, nil)
+}
+
+func (w *gridElement) vgap() base.Length {
+	if w.rowGap != 0 {
+		return w.rowGap
+	}
+	return calculateVGap(nil, nil)
+}
+
+// trackCount returns the number of tracks along one axis, including any
+// implicit tracks required to hold the children.
+func (w *gridElement) trackCount(tracks []GridTrack, span func(*gridCellInfo) gridSpan) int {
+	count := len(tracks)
+	for i := range w.cells {
+		if end := span(&w.cells[i]).end(); end > count {
+			count = end
+		}
+	}
+	return count
+}
+
+func gridColumn(cell *gridCellInfo) gridSpan {
+	return cell.column
+}
+
+func gridRow(cell *gridCellInfo) gridSpan {
+	return cell.row
+}
+
+// sizeGridTracks determines the size of each track along one axis.  The
+// function minSize is called exactly once for each cell, and returns the
+// minimum size of that cell along the axis.  Any space required to meet
+// minTotal, or available up to maxTotal, is shared by the flexible tracks.
+func sizeGridTracks(tracks []GridTrack, count int, gap base.Length, spans []gridSpan, minSize func(int) base.Length, minTotal, maxTotal base.Length) []base.Length {
+	sizes := make([]base.Length, count)
+	totalFlex := 0
+	for i := range sizes {
+		if t := gridTrackAt(tracks, i); t.Sizing == TrackFixed {
+			sizes[i] = t.Size
+		} else if t.Sizing == TrackFlex {
+			totalFlex += t.flex()
+		}
+	}
+
+	// Cells in a single track set the minimum size of that track.
+	for i, s := range spans {
+		if s.count == 1 {
+			size := minSize(i)
+			if gridTrackAt(tracks, s.start).Sizing != TrackFixed {
+				sizes[s.start] = max(sizes[s.start], size)
+			}
+		}
+	}
+
+	// Cells that span several tracks share any additional space required
+	// amongst the spanned tracks that are not fixed.
+	for i, s := range spans {
+		if s.count == 1 {
+			continue
+		}
+
+		size := minSize(i)
+		have := gap.Scale(s.count-1, 1)
+		growable := 0
+		for j := s.start; j < s.end(); j++ {
+			have += sizes[j]
+			if gridTrackAt(tracks, j).Sizing != TrackFixed {
+				growable++
+			}
+		}
+		if size <= have || growable == 0 {
+			continue
+		}
+
+		extra := size - have
+		k := 0
+		for j := s.start; j < s.end(); j++ {
+			if gridTrackAt(tracks, j).Sizing != TrackFixed {
+				sizes[j] += extra.Scale(k+1, growable) - extra.Scale(k, growable)
+				k++
+			}
+		}
+	}
+
+	// Distribute any extra space to the flexible tracks.
+	if totalFlex > 0 {
+		total := sumGridTracks(sizes, gap)
+		extra := base.Length(0)
+		if maxTotal != base.Inf && maxTotal > total {
+			extra = maxTotal - total
+		} else if minTotal > total {
+			extra = minTotal - total
+		}
+
+		if extra > 0 {
+			for i := range sizes {
+				if t := gridTrackAt(tracks, i); t.Sizing == TrackFlex {
+					sizes[i] += extra.Scale(t.flex(), totalFlex)
+				}
+			}
+		}
+	}
+
+	return sizes
+}
+
+func gridTrackAt(tracks []GridTrack, i int) GridTrack {
+	if i < len(tracks) {
+		return tracks[i]
+	}
+	return GridTrack{}
+}
+
+func (t GridTrack) flex() int {
+	if t.Flex < 1 {
+		return 1
+	}
+	return t.Flex
+}
+
+func sumGridTracks(sizes []base.Length, gap base.Length) base.Length {
+	if len(sizes) == 0 {
+		return 0
+	}
+
+	total := gap.Scale(len(sizes)-1, 1)
+	for _, v := range sizes {
+		total += v
+	}
+	return total
+}
+
+// spanSize returns the size of the cell that spans the tracks.
+func spanSize(sizes []base.Length, gap base.Length, span gridSpan) base.Length {
+	return sumGridTracks(sizes[span.start:span.end()], gap)
+}
+
+func (w *gridElement) spans(span func(*gridCellInfo) gridSpan) []gridSpan {
+	spans := make([]gridSpan, len(w.cells))
+	for i := range w.cells {
+		spans[i] = span(&w.cells[i])
+	}
+	return spans
+}
+
+func (w *gridElement) sizeColumns(minWidth, maxWidth base.Length) []base.Length {
+	return sizeGridTracks(
+		w.columns, w.trackCount(w.columns, gridColumn), w.hgap(), w.spans(gridColumn),
+		func(i int) base.Length {
+			return w.children[i].MinIntrinsicWidth(base.Inf)
+		},
+		minWidth, maxWidth,
+	)
+}
+
+func (w *gridElement) Layout(bc base.Constraints) base.Size {
+	if size, ok := w.cache.Layout(bc); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.layout(bc)
+	w.cache.SetLayout(bc, size)
+	return size
+}
+
+func (w *gridElement) layout(bc base.Constraints) base.Size {
+	hgap, vgap := w.hgap(), w.vgap()
+
+	// Determine the widths of the columns, and then the heights of the rows
+	// once the width of each cell is known.
+	w.columnWidths = w.sizeColumns(bc.Min.Width, bc.Max.Width)
+	w.rowHeights = sizeGridTracks(
+		w.rows, w.trackCount(w.rows, gridRow), vgap, w.spans(gridRow),
+		func(i int) base.Length {
+			cbc := base.Constraints{
+				Max: base.Size{spanSize(w.columnWidths, hgap, w.cells[i].column), base.Inf},
+			}
+			if w.cells[i].hAlign == Stretch {
+				cbc = cbc.TightenWidth(cbc.Max.Width)
+			}
+			return base.Layout(w.children[i], cbc).Height
+		},
+		bc.Min.Height, bc.Max.Height,
+	)
+
+	// Perform the final layout of the children to fit their cells.
+	for i, v := range w.children {
+		cell := &w.cells[i]
+		cellSize := base.Size{
+			spanSize(w.columnWidths, hgap, cell.column),
+			spanSize(w.rowHeights, vgap, cell.row),
+		}
+		cbc := base.Loose(cellSize)
+		if cell.hAlign == Stretch {
+			cbc = cbc.TightenWidth(cellSize.Width)
+		}
+		if cell.vAlign == Stretch {
+			cbc = cbc.TightenHeight(cellSize.Height)
+		}
+		cell.size = base.Layout(v, cbc)
+	}
+
+	return bc.Constrain(base.Size{
+		sumGridTracks(w.columnWidths, hgap),
+		sumGridTracks(w.rowHeights, vgap),
+	})
+}
+
+func (w *gridElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicHeight(width); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicHeight(width)
+	w.cache.SetMinIntrinsicHeight(width, size)
+	return size
+}
+
+func (w *gridElement) minIntrinsicHeight(width base.Length) base.Length {
+	hgap, vgap := w.hgap(), w.vgap()
+
+	columnWidths := w.sizeColumns(0, width)
+	rowHeights := sizeGridTracks(
+		w.rows, w.trackCount(w.rows, gridRow), vgap, w.spans(gridRow),
+		func(i int) base.Length {
+			return w.children[i].MinIntrinsicHeight(spanSize(columnWidths, hgap, w.cells[i].column))
+		},
+		0, 0,
+	)
+	return sumGridTracks(rowHeights, vgap)
+}
+
+func (w *gridElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicWidth(height); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicWidth(height)
+	w.cache.SetMinIntrinsicWidth(height, size)
+	return size
+}
+
+func (w *gridElement) minIntrinsicWidth(height base.Length) base.Length {
+	// The height of each row is not known, so the children are measured
+	// without a limit on their height.
+	return sumGridTracks(w.sizeColumns(0, 0), w.hgap())
+}
+
+func (w *gridElement) Props() base.Widget {
+	children := []GridCell(nil)
+	if len(w.children) != 0 {
+		children = make([]GridCell, 0, len(w.children))
+		for i, v := range w.children {
+			cell := w.cells[i].props
+			cell.Child = base.PropsOf(v)
+			children = append(children, cell)
+		}
+	}
+
+	return &Grid{
+		Columns:   w.columns,
+		Rows:      w.rows,
+		ColumnGap: w.columnGap,
+		RowGap:    w.rowGap,
+		Children:  children,
+	}
+}
+
+func (w *gridElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	if len(w.children) == 0 {
+		return
+	}
+	if w.columnWidths == nil {
+		// Layout has not been performed, so the sizes of the tracks are
+		// not known.
+		w.layout(base.Tight(base.Size{bounds.Dx(), bounds.Dy()}))
+	}
+
+	// Find the position of the start of each column and row.
+	hgap, vgap := w.hgap(), w.vgap()
+	columnX := gridTrackPositions(w.columnWidths, hgap, bounds.Min.X)
+	rowY := gridTrackPositions(w.rowHeights, vgap, bounds.Min.Y)
+
+	for i, v := range w.children {
+		cell := &w.cells[i]
+		x1, x2 := alignInCell(cell.hAlign,
+			columnX[cell.column.start],
+			columnX[cell.column.start]+spanSize(w.columnWidths, hgap, cell.column),
+			cell.size.Width)
+		y1, y2 := alignInCell(cell.vAlign,
+			rowY[cell.row.start],
+			rowY[cell.row.start]+spanSize(w.rowHeights, vgap, cell.row),
+			cell.size.Height)
+		v.SetBounds(mirrorForDirection(w.parent, w.bounds, base.Rect(x1, y1, x2, y2)))
+	}
+}
+
+func gridTrackPositions(sizes []base.Length, gap base.Length, start base.Length) []base.Length {
+	positions := make([]base.Length, len(sizes))
+	for i, v := range sizes {
+		positions[i] = start
+		start += v + gap
+	}
+	return positions
+}
+
+// alignInCell returns the start and end of a child within a cell along one
+// axis.
+func alignInCell(align CrossAxisAlign, start, end, size base.Length) (base.Length, base.Length) {
+	switch align {
+	case CrossCenter:
+		start += (end - start - size) / 2
+		return start, start + size
+	case CrossEnd:
+		return end - size, end
+	case Stretch:
+		return start, end
+	}
+	return start, start + size
+}
+
+func (w *gridElement) updateProps(data *Grid) (err error) {
+	// Update properties
+	w.columns = data.Columns
+	w.rows = data.Rows
+	w.columnGap = data.ColumnGap
+	w.rowGap = data.RowGap
+	w.children, err = base.DiffChildren(w.parent, w.children, gridChildWidgets(data.Children))
+	// Clear cached values
+	w.cells = updateGridCells(w.children, data.Children, w.cells)
+	w.cache.Invalidate()
+	w.columnWidths = nil
+	w.rowHeights = nil
+	return err
+}
+
+func (w *gridElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Grid))
+}
@@ -0,0 +1,221 @@
+package goey
+
+import (
+	"errors"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func testingGridForm() []GridCell {
+	return []GridCell{
+		{Row: 0, Column: 0, Child: &Label{Text: "Name:"}},
+		{Row: 0, Column: 1, Child: &TextInput{Value: "A"}},
+		{Row: 1, Column: 0, Child: &Label{Text: "Description:"}},
+		{Row: 1, Column: 1, Child: &TextInput{Value: "B"}},
+		{Row: 2, Column: 0, ColumnSpan: 2, HAlign: CrossEnd, Child: &Button{Text: "OK"}},
+	}
+}
+
+func TestGridMount(t *testing.T) {
+	columns := []GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}}
+
+	testingMountWidgets(t,
+		&Grid{},
+		&Grid{Columns: columns, Children: testingGridForm()},
+		&Grid{Columns: columns, ColumnGap: 5 * DIP, RowGap: 7 * DIP, Children: testingGridForm()},
+		&Grid{Columns: []GridTrack{{Sizing: TrackFixed, Size: 100 * DIP}}, Rows: []GridTrack{{Sizing: TrackFlex, Flex: 2}}, Children: testingGridForm()},
+	)
+}
+
+func TestGridClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Grid{},
+		&Grid{Children: testingGridForm()},
+	)
+}
+
+func TestGridUpdateProps(t *testing.T) {
+	columns := []GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}}
+	cells := []GridCell{
+		{Row: 0, Column: 0, Child: &Button{Text: "A"}},
+		{Row: 1, Column: 1, RowSpan: 2, Child: &Label{Text: "B"}},
+	}
+
+	testingUpdateWidgets(t, []base.Widget{
+		&Grid{},
+		&Grid{Columns: columns, Children: testingGridForm()},
+		&Grid{Children: cells},
+	}, []base.Widget{
+		&Grid{Columns: columns, Children: testingGridForm()},
+		&Grid{RowGap: 5 * DIP},
+		&Grid{Columns: columns, Children: testingGridForm()},
+	})
+}
+
+func TestGridUpdatePropsError(t *testing.T) {
+	err := errors.New("Mock error 1")
+	size := base.Size{20 * DIP, 10 * DIP}
+
+	elem, mountErr := (&Grid{Children: []GridCell{
+		{Child: &mock.Widget{Key: "a", Size: size}},
+		{Column: 1, Child: &mock.Widget{Key: "b", Size: size}},
+		{Column: 2, Child: &mock.Widget{Key: "c", Size: size}},
+	}}).Mount(base.Control{})
+	if mountErr != nil {
+		t.Fatalf("Failed to mount, %s", mountErr)
+	}
+	defer elem.Close()
+
+	// A failed update leaves the unmatched children in place, and the grid
+	// must still be usable.
+	if got := elem.UpdateProps(&Grid{Children: []GridCell{
+		{Child: &mock.Widget{Key: "a", Err: err}},
+	}}); got != err {
+		t.Errorf("Incorrect error on update, got %v, want %v", got, err)
+	}
+	in := elem.(*gridElement)
+	if got := len(in.cells); got != len(in.children) {
+		t.Errorf("Incorrect number of cells, got %d, want %d", got, len(in.children))
+	}
+	size = in.Layout(base.Loose(base.Size{200 * DIP, 100 * DIP}))
+	in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+	in.MinIntrinsicHeight(base.Inf)
+	in.MinIntrinsicWidth(base.Inf)
+	if got := len(in.Props().(*Grid).Children); got != len(in.children) {
+		t.Errorf("Incorrect number of children in props, got %d, want %d", got, len(in.children))
+	}
+}
+
+func TestGridLayout(t *testing.T) {
+	cases := []struct {
+		columns     []GridTrack
+		rows        []GridTrack
+		cells       []GridCell
+		sizes       []base.Size
+		constraints base.Constraints
+		size        base.Size
+		bounds      []base.Rectangle
+	}{
+		{
+			nil, nil, nil, nil,
+			base.Loose(base.Size{200 * DIP, 100 * DIP}), base.Size{}, nil,
+		},
+		{
+			[]GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}}, nil,
+			[]GridCell{{}, {Column: 1}, {Row: 1, ColumnSpan: 2}},
+			[]base.Size{{20 * DIP, 10 * DIP}, {30 * DIP, 15 * DIP}, {101 * DIP, 20 * DIP}},
+			base.Loose(base.Size{200 * DIP, 100 * DIP}), base.Size{200 * DIP, 46 * DIP},
+			[]base.Rectangle{
+				base.Rect(0, 0, 40*DIP, 15*DIP),
+				base.Rect(51*DIP, 0, 200*DIP, 15*DIP),
+				base.Rect(0, 26*DIP, 200*DIP, 46*DIP),
+			},
+		},
+		{
+			[]GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}},
+			[]GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}},
+			[]GridCell{{HAlign: CrossEnd, VAlign: CrossCenter}, {Column: 1}, {Row: 1, ColumnSpan: 2}},
+			[]base.Size{{20 * DIP, 10 * DIP}, {30 * DIP, 16 * DIP}, {101 * DIP, 20 * DIP}},
+			base.Tight(base.Size{200 * DIP, 100 * DIP}), base.Size{200 * DIP, 100 * DIP},
+			[]base.Rectangle{
+				base.Rect(20*DIP, 3*DIP, 40*DIP, 13*DIP),
+				base.Rect(51*DIP, 0, 200*DIP, 16*DIP),
+				base.Rect(0, 27*DIP, 200*DIP, 100*DIP),
+			},
+		},
+	}
+
+	for i, v := range cases {
+		children := make([]base.Element, 0, len(v.sizes))
+		for _, u := range v.sizes {
+			children = append(children, mock.New(u))
+		}
+		in := gridElement{
+			children: children,
+			cells:    updateGridCells(children, v.cells, nil),
+			columns:  v.columns,
+			rows:     v.rows,
+		}
+
+		size := in.Layout(v.constraints)
+		if size != v.size {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, v.size)
+		}
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+func TestGridMinIntrinsic(t *testing.T) {
+	cases := []struct {
+		columns            []GridTrack
+		cells              []GridCell
+		sizes              []base.Size
+		minIntrinsicWidth  base.Length
+		minIntrinsicHeight base.Length
+	}{
+		{nil, nil, nil, 0, 0},
+		{
+			[]GridTrack{{Sizing: TrackFixed, Size: 10 * DIP}, {Sizing: TrackFixed, Size: 20 * DIP}},
+			nil, nil, 41 * DIP, 0,
+		},
+		{
+			[]GridTrack{{Sizing: TrackAuto}, {Sizing: TrackFlex}},
+			[]GridCell{{}, {Column: 1}, {Row: 1}},
+			[]base.Size{{20 * DIP, 10 * DIP}, {30 * DIP, 15 * DIP}, {25 * DIP, 20 * DIP}},
+			66 * DIP, 46 * DIP,
+		},
+		{
+			[]GridTrack{{Sizing: TrackFixed, Size: 50 * DIP}, {Sizing: TrackAuto}},
+			[]GridCell{{}, {Column: 1}, {Row: 1, ColumnSpan: 2}},
+			[]base.Size{{20 * DIP, 10 * DIP}, {30 * DIP, 15 * DIP}, {100 * DIP, 20 * DIP}},
+			100 * DIP, 46 * DIP,
+		},
+		{
+			nil,
+			[]GridCell{{RowSpan: 2}, {Column: 1}, {Row: 1, Column: 1}},
+			[]base.Size{{20 * DIP, 50 * DIP}, {30 * DIP, 15 * DIP}, {25 * DIP, 20 * DIP}},
+			61 * DIP, 50 * DIP,
+		},
+	}
+
+	for i, v := range cases {
+		children := mock.NewList(v.sizes...)
+		in := gridElement{
+			children: children,
+			cells:    updateGridCells(children, v.cells, nil),
+			columns:  v.columns,
+		}
+
+		if value := in.MinIntrinsicHeight(base.Inf); value != v.minIntrinsicHeight {
+			t.Errorf("Incorrect min intrinsic height on case %d, got %s, want %s", i, value, v.minIntrinsicHeight)
+		}
+		if value := in.MinIntrinsicWidth(base.Inf); value != v.minIntrinsicWidth {
+			t.Errorf("Incorrect min intrinsic width on case %d, got %s, want %s", i, value, v.minIntrinsicWidth)
+		}
+	}
+}
+
+func TestGridRightToLeft(t *testing.T) {
+	children := mock.NewList(base.Size{20 * DIP, 10 * DIP}, base.Size{30 * DIP, 10 * DIP})
+	in := gridElement{
+		parent:   base.Control{Context: (*base.Context)(nil).WithDirection(base.RightToLeft)},
+		children: children,
+		cells:    updateGridCells(children, []GridCell{{}, {Column: 1}}, nil),
+	}
+
+	size := in.Layout(base.Loose(base.Size{200 * DIP, 100 * DIP}))
+	in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+	if got, want := children[0].Bounds(), base.Rect(41*DIP, 0, 61*DIP, 10*DIP); got != want {
+		t.Errorf("Incorrect bounds for first child, got %s, want %s", got, want)
+	}
+	if got, want := children[1].Bounds(), base.Rect(0, 0, 30*DIP, 10*DIP); got != want {
+		t.Errorf("Incorrect bounds for second child, got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"github.com/lxn/win"
+)
+
+func (w *gridElement) SetOrder(previous win.HWND) win.HWND {
+	for _, v := range w.children {
+		previous = v.SetOrder(previous)
+	}
+	return previous
+}
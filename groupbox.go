@@ -0,0 +1,136 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	groupboxKind = base.NewKind("bitbucket.org/rj/goey.GroupBox")
+)
+
+const (
+	// groupboxPadding is the space between the frame of a group box and its
+	// child, along the sides and the bottom.
+	groupboxPadding = 11 * DIP
+)
+
+// GroupBox describes a widget that draws a frame, with a caption, around its
+// child.  It is used to group related controls, such as a set of options.
+//
+// The frame is a native control, and so its appearance matches the platform.
+// The group box will be at least wide enough to show the caption, and space
+// will be added above the child for the caption, and on the other sides for
+// the frame.
+type GroupBox struct {
+	Text  string      // Caption shown at the top of the frame.
+	Child base.Widget // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*GroupBox) Kind() *base.Kind {
+	return &groupboxKind
+}
+
+// Mount creates a group box in the GUI.  The newly created widget will be a
+// child of the widget specified by parent.
+func (w *GroupBox) Mount(parent base.Control) (base.Element, error) {
+	// Forward to the platform-dependant code
+	return w.mount(parent)
+}
+
+func (w *groupboxElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *groupboxElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.Control.Close()
+}
+
+func (*groupboxElement) Kind() *base.Kind {
+	return &groupboxKind
+}
+
+// insets returns the space between the edges of the group box and its child.
+func (w *groupboxElement) insets() Insets {
+	top := groupboxPadding
+	if caption := w.captionSize(); caption.Height > 0 {
+		top = caption.Height + labelGap
+	}
+	return Insets{top, groupboxPadding, groupboxPadding, groupboxPadding}
+}
+
+// minWidth returns the width required to show the caption.
+func (w *groupboxElement) minWidth() base.Length {
+	return w.captionSize().Width + 2*groupboxPadding
+}
+
+func (w *groupboxElement) Layout(bc base.Constraints) base.Size {
+	insets := w.insets()
+	hinset := insets.Left + insets.Right
+	vinset := insets.Top + insets.Bottom
+
+	if w.child == nil {
+		return bc.Constrain(base.Size{w.minWidth(), vinset})
+	}
+
+	size := base.Layout(w.child, bc.Inset(hinset, vinset))
+	return bc.Constrain(base.Size{
+		max(size.Width+hinset, w.minWidth()),
+		size.Height + vinset,
+	})
+}
+
+func (w *groupboxElement) MinIntrinsicHeight(width base.Length) base.Length {
+	insets := w.insets()
+	vinset := insets.Top + insets.Bottom
+
+	if w.child == nil {
+		return vinset
+	}
+	width = guardInf(width, max(0, width-insets.Left-insets.Right))
+	return w.child.MinIntrinsicHeight(width) + vinset
+}
+
+func (w *groupboxElement) MinIntrinsicWidth(height base.Length) base.Length {
+	insets := w.insets()
+	hinset := insets.Left + insets.Right
+
+	if w.child == nil {
+		return w.minWidth()
+	}
+	height = guardInf(height, max(0, height-insets.Top-insets.Bottom))
+	return max(w.child.MinIntrinsicWidth(height)+hinset, w.minWidth())
+}
+
+func (w *groupboxElement) SetBounds(bounds base.Rectangle) {
+	w.setControlBounds(bounds)
+
+	// The child is a sibling of the frame, and so is positioned using the
+	// same coordinates.
+	if w.child != nil {
+		insets := w.insets()
+		w.child.SetBounds(base.Rect(
+			bounds.Min.X+insets.Left, bounds.Min.Y+insets.Top,
+			bounds.Max.X-insets.Right, bounds.Max.Y-insets.Bottom,
+		))
+	}
+}
+
+// setHidden hides or shows the frame and the child.  The child is not inside
+// the frame, and so must be hidden separately.
+func (w *groupboxElement) setHidden(value bool) {
+	w.Control.setHidden(value)
+	setHiddenElements(w.child, value)
+}
+
+func (w *groupboxElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*GroupBox))
+}
@@ -0,0 +1,53 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+func (w *GroupBox) mount(parent base.Control) (base.Element, error) {
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	retval := &groupboxElement{
+		parent: parent,
+		text:   w.Text,
+		child:  child,
+	}
+	return retval, err
+}
+
+type groupboxElement struct {
+	Control
+	parent base.Control
+	text   string
+	child  base.Element
+}
+
+func (w *groupboxElement) captionSize() base.Size {
+	if w.text == "" {
+		return base.Size{}
+	}
+	return measureText(w.text)
+}
+
+func (w *groupboxElement) Props() base.Widget {
+	return &GroupBox{
+		Text:  w.text,
+		Child: base.PropsOf(w.child),
+	}
+}
+
+func (w *groupboxElement) setControlBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+}
+
+func (w *groupboxElement) updateProps(data *GroupBox) (err error) {
+	w.text = data.Text
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	return err
+}
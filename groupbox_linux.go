@@ -0,0 +1,88 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func (w *GroupBox) mount(parent base.Control) (base.Element, error) {
+	// Create the frame, with a label that can be measured for layout.
+	control, err := gtk.FrameNew("")
+	if err != nil {
+		return nil, err
+	}
+	label, err := gtk.LabelNew(w.Text)
+	if err != nil {
+		control.Destroy()
+		return nil, err
+	}
+	control.SetLabelWidget(label)
+	parent.Handle.Add(control)
+	control.ShowAll()
+	label.SetVisible(w.Text != "")
+
+	// The frame does not contain its child.  Instead, the child is added to
+	// the parent after the frame, so that it is drawn on top.
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		control.Destroy()
+		return nil, err
+	}
+
+	retval := &groupboxElement{
+		Control: Control{handle: &control.Widget},
+		label:   label,
+		parent:  parent,
+		child:   child,
+	}
+	control.Connect("destroy", groupboxOnDestroy, retval)
+
+	return retval, err
+}
+
+type groupboxElement struct {
+	Control
+	label  *gtk.Label
+	parent base.Control
+	child  base.Element
+}
+
+func groupboxOnDestroy(widget *gtk.Frame, mounted *groupboxElement) {
+	mounted.handle = nil
+}
+
+func (w *groupboxElement) captionSize() base.Size {
+	if !w.label.GetVisible() {
+		return base.Size{}
+	}
+	_, width := w.label.GetPreferredWidth()
+	_, height := w.label.GetPreferredHeight()
+	return base.Size{base.FromPixelsX(width), base.FromPixelsY(height)}
+}
+
+func (w *groupboxElement) Props() base.Widget {
+	text, err := w.label.GetText()
+	if err != nil {
+		panic("Could not get text, " + err.Error())
+	}
+
+	return &GroupBox{
+		Text:  text,
+		Child: base.PropsOf(w.child),
+	}
+}
+
+func (w *groupboxElement) setControlBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+}
+
+func (w *groupboxElement) updateProps(data *GroupBox) (err error) {
+	w.label.SetText(data.Text)
+	w.label.SetVisible(data.Text != "")
+
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	return err
+}
@@ -0,0 +1,37 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+func TestGroupBoxMount(t *testing.T) {
+	testingMountWidgets(t,
+		&GroupBox{Text: "A", Child: &Button{Text: "A"}},
+		&GroupBox{Text: "B", Child: &VBox{Children: []base.Widget{
+			&Checkbox{Text: "C1"}, &Checkbox{Text: "C2"},
+		}}},
+		&GroupBox{Child: &Label{Text: "D"}},
+		&GroupBox{Text: "E"},
+	)
+}
+
+func TestGroupBoxClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&GroupBox{Text: "A", Child: &Button{Text: "A"}},
+		&GroupBox{Text: "B"},
+	)
+}
+
+func TestGroupBoxUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&GroupBox{Text: "A", Child: &Button{Text: "A"}},
+		&GroupBox{Text: "B", Child: &Label{Text: "B"}},
+		&GroupBox{},
+	}, []base.Widget{
+		&GroupBox{Text: "AB", Child: &Button{Text: "AB"}},
+		&GroupBox{Child: &Label{Text: "BC"}},
+		&GroupBox{Text: "C", Child: &Button{Text: "C"}},
+	})
+}
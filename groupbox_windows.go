@@ -0,0 +1,88 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"syscall"
+
+	"bitbucket.org/rj/goey/base"
+	win2 "bitbucket.org/rj/goey/internal/syscall"
+	"github.com/lxn/win"
+)
+
+func (w *GroupBox) mount(parent base.Control) (base.Element, error) {
+	// Create the control.  A group box does not contain its child, which is
+	// instead placed on top of the frame as a sibling.
+	const STYLE = win.WS_CHILD | win.WS_VISIBLE | win.BS_GROUPBOX
+	hwnd, text, err := createControlWindow(0, &button.className[0], w.Text, STYLE, parent.HWnd)
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		win.DestroyWindow(hwnd)
+		return nil, err
+	}
+
+	retval := &groupboxElement{
+		Control: Control{hWnd: hwnd},
+		parent:  parent,
+		text:    text,
+		child:   child,
+	}
+	return retval, err
+}
+
+type groupboxElement struct {
+	Control
+	parent base.Control
+	text   []uint16
+	child  base.Element
+}
+
+func (w *groupboxElement) captionSize() base.Size {
+	if len(w.text) <= 1 {
+		// The caption is empty, as the text only contains the terminating
+		// nul.
+		return base.Size{}
+	}
+	width, height := w.CalcRect(w.text)
+	return base.Size{base.FromPixelsX(int(width)), base.FromPixelsY(int(height))}
+}
+
+func (w *groupboxElement) Props() base.Widget {
+	return &GroupBox{
+		Text:  w.Control.Text(),
+		Child: base.PropsOf(w.child),
+	}
+}
+
+func (w *groupboxElement) setControlBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+	// Group boxes don't repaint when resized.  This forces a repaint.
+	win.InvalidateRect(w.hWnd, nil, true)
+}
+
+func (w *groupboxElement) SetOrder(previous win.HWND) win.HWND {
+	// The frame is placed before the child, which matches the order used in
+	// dialog templates.
+	previous = w.Control.SetOrder(previous)
+	if w.child != nil {
+		previous = w.child.SetOrder(previous)
+	}
+	return previous
+}
+
+func (w *groupboxElement) updateProps(data *GroupBox) (err error) {
+	text, err := syscall.UTF16FromString(data.Text)
+	if err != nil {
+		return err
+	}
+	w.text = text
+	win2.SetWindowText(w.hWnd, &text[0])
+
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	return err
+}
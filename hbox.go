@@ -6,20 +6,35 @@ import (
 
 var (
 	hboxKind = base.NewKind("bitbucket.org/rj/goey.HBox")
+
+	// layoutCacheDisabled makes containers ignore the results stored in
+	// their LayoutCache.  It is only set by benchmarks, to measure the cost
+	// of layout without caching.
+	layoutCacheDisabled = false
 )
 
 // HBox describes a layout widget that arranges its child widgets into a row.
 // Children are positioned in order from the left towards the right.  The main
 // axis for alignment is therefore horizontal, with the cross axis for alignment is vertical.
+// For a right-to-left layout, the order is reversed, so that the first child
+// is on the right.
 //
 // The size of the box will try to set a width sufficient to contain all of its
 // children.  Extra space will be distributed according to the value of
 // AlignMain.  Subject to the box constraints during layout, the height should
 // match the largest minimum height of the child widgets.
+//
+// The gaps between children follow the platform's guidelines, which depend on
+// the types of the neighbouring widgets.  If UseSpacing is set, Spacing is
+// used for every gap instead, even if it is zero.  If the children are wider
+// than the box, then any children wrapped with Expand and a non-zero Shrink
+// factor will be narrowed to fit, but not below their minimum intrinsic width.
 type HBox struct {
 	AlignMain  MainAxisAlign  // Control distribution of excess horizontal space when positioning children.
 	AlignCross CrossAxisAlign // Control distribution of excess vertical space when positioning children.
 	Children   []base.Widget  // Children.
+	Spacing    base.Length    // Space between adjacent children, if UseSpacing is set.
+	UseSpacing bool           // If set, Spacing replaces the platform's default gaps.
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -31,16 +46,11 @@ func (*HBox) Kind() *base.Kind {
 // Mount creates a horizontal layout for child widgets in the GUI.
 // The newly created widget will be a child of the widget specified by parent.
 func (w *HBox) Mount(parent base.Control) (base.Element, error) {
-	c := make([]base.Element, 0, len(w.Children))
-
-	// Mount all of the children
-	for _, v := range w.Children {
-		mountedChild, err := v.Mount(parent)
-		if err != nil {
-			base.CloseElements(c)
-			return nil, err
-		}
-		c = append(c, mountedChild)
+	// Mount all of the children.  If the parent's context is set to continue
+	// on error, the box is mounted even if some of the children failed.
+	c, err := base.DiffChildren(parent, nil, w.Children)
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
 	}
 
 	// Record the flex factor for all children
@@ -51,9 +61,11 @@ func (w *HBox) Mount(parent base.Control) (base.Element, error) {
 		children:     c,
 		alignMain:    w.AlignMain,
 		alignCross:   w.AlignCross,
+		spacing:      w.Spacing,
+		useSpacing:   w.UseSpacing,
 		childrenInfo: ci,
 		totalFlex:    totalFlex,
-	}, nil
+	}, err
 }
 
 type hboxElement struct {
@@ -61,15 +73,30 @@ type hboxElement struct {
 	children   []base.Element
 	alignMain  MainAxisAlign
 	alignCross CrossAxisAlign
+	spacing    base.Length
+	useSpacing bool
 
 	childrenInfo []boxElementInfo
 	totalWidth   base.Length
 	totalFlex    int
+	baseline     base.Length
+	bounds       base.Rectangle
+	cache        base.LayoutCache
 }
 
 type boxElementInfo struct {
-	size base.Size
-	flex int
+	size     base.Size
+	flex     int
+	shrink   int
+	baseline base.Length
+}
+
+func (w *hboxElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *hboxElement) Children() []base.Element {
+	return w.children
 }
 
 func (w *hboxElement) Close() {
@@ -82,7 +109,24 @@ func (*hboxElement) Kind() *base.Kind {
 	return &hboxKind
 }
 
+// gap returns the space to insert between a pair of adjacent children.
+func (w *hboxElement) gap(previous, current base.Element) base.Length {
+	if w.useSpacing {
+		return w.spacing
+	}
+	return calculateHGap(previous, current)
+}
+
 func (w *hboxElement) Layout(bc base.Constraints) base.Size {
+	if size, ok := w.cache.Layout(bc); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.layout(bc)
+	w.cache.SetLayout(bc, size)
+	return size
+}
+
+func (w *hboxElement) layout(bc base.Constraints) base.Size {
 	if len(w.children) == 0 {
 		w.totalWidth = 0
 		return bc.Constrain(base.Size{})
@@ -92,7 +136,7 @@ func (w *hboxElement) Layout(bc base.Constraints) base.Size {
 	cbc := bc
 	if w.alignMain == Homogeneous {
 		count := len(w.children)
-		gap := calculateHGap(nil, nil)
+		gap := w.gap(nil, nil)
 		cbc.TightenWidth(cbc.Max.Width.Scale(1, count) - gap.Scale(count-1, count))
 	} else {
 		cbc.Min.Width = 0
@@ -113,23 +157,64 @@ func (w *hboxElement) Layout(bc base.Constraints) base.Size {
 	previous := base.Element(nil)
 	for i, v := range w.children {
 		// Determine what gap needs to be inserted between the elements.
-		if i > 0 {
-			if w.alignMain.IsPacked() {
-				width += calculateHGap(previous, v)
-			} else {
-				width += calculateHGap(nil, nil)
+		// Hidden elements are skipped, so that they do not leave a gap.
+		if !isHiddenElement(v) {
+			if previous != nil {
+				if w.alignMain.IsPacked() {
+					width += w.gap(previous, v)
+				} else {
+					width += w.gap(nil, nil)
+				}
 			}
+			previous = v
 		}
-		previous = v
 
 		// Perform layout of the element.  Track impact on width and height.
-		size := v.Layout(cbc)
+		size := base.Layout(v, cbc)
 		w.childrenInfo[i].size = size
 		width += size.Width
 		height = max(height, size.Height)
 	}
 	w.totalWidth = width
 
+	// Need to reduce width of any widgets that can shrink, if the children
+	// overflow the box.  Once a widget reaches its minimum width, the
+	// remaining overflow is divided amongst the other widgets.
+	if totalShrink(w.childrenInfo) > 0 && w.alignMain != Homogeneous && bc.HasBoundedWidth() && w.totalWidth > bc.Max.Width {
+		minWidths := make([]base.Length, len(w.children))
+		for i, v := range w.childrenInfo {
+			if v.shrink > 0 {
+				minWidths[i] = min(v.size.Width, w.children[i].MinIntrinsicWidth(cbc.Max.Height))
+			}
+		}
+
+		for w.totalWidth > bc.Max.Width {
+			overflow := w.totalWidth - bc.Max.Width
+			total := shrinkableTotal(w.childrenInfo, func(i int) bool {
+				return w.childrenInfo[i].size.Width > minWidths[i]
+			})
+			if total == 0 {
+				break
+			}
+
+			oldTotalWidth := w.totalWidth
+			for i, v := range w.childrenInfo {
+				if v.shrink > 0 && v.size.Width > minWidths[i] {
+					oldWidth := v.size.Width
+					fbc := cbc.TightenWidth(max(minWidths[i], oldWidth-overflow.Scale(v.shrink, total)))
+					size := base.Layout(w.children[i], fbc)
+					w.childrenInfo[i].size = size
+					w.totalWidth += size.Width - oldWidth
+					height = max(height, size.Height)
+				}
+			}
+			if w.totalWidth >= oldTotalWidth {
+				// No progress, possibly because of rounding.
+				break
+			}
+		}
+	}
+
 	// Need to adjust height to any widgets that have flex
 	if w.totalFlex > 0 {
 		extraWidth := base.Length(0)
@@ -144,7 +229,7 @@ func (w *hboxElement) Layout(bc base.Constraints) base.Size {
 				if v.flex > 0 {
 					oldWidth := v.size.Width
 					fbc := cbc.TightenWidth(v.size.Width + extraWidth.Scale(v.flex, w.totalFlex))
-					size := w.children[i].Layout(fbc)
+					size := base.Layout(w.children[i], fbc)
 					w.childrenInfo[i].size = size
 					w.totalWidth += size.Width - oldWidth
 				}
@@ -155,14 +240,66 @@ func (w *hboxElement) Layout(bc base.Constraints) base.Size {
 	if w.alignCross == Stretch {
 		return bc.Constrain(base.Size{width, cbc.Min.Height})
 	}
+	if w.alignCross == Baseline {
+		height = w.layoutBaseline()
+	}
 	return bc.Constrain(base.Size{width, height})
 }
 
+// layoutBaseline determines the position of the shared baseline, and returns
+// the height required to fit the children once aligned.
+func (w *hboxElement) layoutBaseline() base.Length {
+	ascent, descent := base.Length(0), base.Length(0)
+	for i, v := range w.children {
+		height := w.childrenInfo[i].size.Height
+		baseline := childBaseline(v, height)
+		w.childrenInfo[i].baseline = baseline
+		ascent = max(ascent, baseline)
+		descent = max(descent, height-baseline)
+	}
+	w.baseline = ascent
+	return ascent + descent
+}
+
+// childBaseline returns the baseline for a child with the specified height.
+// Children that do not display text are aligned by their bottom edge.
+func childBaseline(elem base.Element, height base.Length) base.Length {
+	if baseline, ok := base.BaselineOf(elem, height); ok {
+		return baseline
+	}
+	return height
+}
+
 func (w *hboxElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicHeight(width); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicHeight(width)
+	w.cache.SetMinIntrinsicHeight(width, size)
+	return size
+}
+
+func (w *hboxElement) minIntrinsicHeight(width base.Length) base.Length {
 	if len(w.children) == 0 {
 		return 0
 	}
 
+	if w.alignCross == Baseline {
+		if w.alignMain == Homogeneous {
+			width = guardInf(width, width.Scale(1, len(w.children)))
+		} else {
+			width = base.Inf
+		}
+		ascent, descent := base.Length(0), base.Length(0)
+		for _, v := range w.children {
+			height := v.MinIntrinsicHeight(width)
+			baseline := childBaseline(v, height)
+			ascent = max(ascent, baseline)
+			descent = max(descent, height-baseline)
+		}
+		return ascent + descent
+	}
+
 	if w.alignMain == Homogeneous {
 		width = guardInf(width, width.Scale(1, len(w.children)))
 		size := w.children[0].MinIntrinsicHeight(width)
@@ -180,17 +317,32 @@ func (w *hboxElement) MinIntrinsicHeight(width base.Length) base.Length {
 }
 
 func (w *hboxElement) MinIntrinsicWidth(height base.Length) base.Length {
-	if len(w.children) == 0 {
+	if size, ok := w.cache.MinIntrinsicWidth(height); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicWidth(height)
+	w.cache.SetMinIntrinsicWidth(height, size)
+	return size
+}
+
+func (w *hboxElement) minIntrinsicWidth(height base.Length) base.Length {
+	// Hidden elements have zero width, and do not need any gaps.
+	count := visibleCount(w.children)
+	if count == 0 {
 		return 0
 	}
 
-	size := w.children[0].MinIntrinsicWidth(height)
+	size := base.Length(0)
 	if w.alignMain.IsPacked() {
-		previous := w.children[0]
-		for _, v := range w.children[1:] {
-			// Add the preferred gap between this pair of widgets
-			size += calculateHGap(previous, v)
-			previous = v
+		previous := base.Element(nil)
+		for _, v := range w.children {
+			if !isHiddenElement(v) {
+				// Add the preferred gap between this pair of widgets
+				if previous != nil {
+					size += w.gap(previous, v)
+				}
+				previous = v
+			}
 			// Find minimum size for this widget, and update
 			size += v.MinIntrinsicWidth(height)
 		}
@@ -198,43 +350,74 @@ func (w *hboxElement) MinIntrinsicWidth(height base.Length) base.Length {
 	}
 
 	if w.alignMain == Homogeneous {
-		for _, v := range w.children[1:] {
+		for _, v := range w.children {
 			size = max(size, v.MinIntrinsicWidth(height))
 		}
 
 		// Add a minimum gap between the controls.
-		size = size.Scale(len(w.children), 1) + calculateHGap(nil, nil).Scale(len(w.children)-1, 1)
+		size = size.Scale(count, 1) + w.gap(nil, nil).Scale(count-1, 1)
 		return size
 	}
 
-	for _, v := range w.children[1:] {
+	for _, v := range w.children {
 		size += v.MinIntrinsicWidth(height)
 	}
 
 	// Add a minimum gap between the controls.
 	if w.alignMain == SpaceBetween {
-		size += calculateHGap(nil, nil).Scale(len(w.children)-1, 1)
+		size += w.gap(nil, nil).Scale(count-1, 1)
 	} else {
-		size += calculateHGap(nil, nil).Scale(len(w.children)+1, 1)
+		size += w.gap(nil, nil).Scale(count+1, 1)
 	}
 
 	return size
 }
 
+func (w *hboxElement) Props() base.Widget {
+	children := []base.Widget(nil)
+	if len(w.children) != 0 {
+		children = make([]base.Widget, 0, len(w.children))
+		for _, v := range w.children {
+			children = append(children, base.PropsOf(v))
+		}
+	}
+
+	return &HBox{
+		AlignMain:  w.alignMain,
+		AlignCross: w.alignCross,
+		Children:   children,
+		Spacing:    w.spacing,
+		UseSpacing: w.useSpacing,
+	}
+}
+
 func (w *hboxElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	if len(w.children) == 0 {
 		return
 	}
 
+	// Hidden elements do not take a share of the space, or any gaps.
+	count := visibleCount(w.children)
+
 	if w.alignMain == Homogeneous {
-		gap := calculateHGap(nil, nil)
+		gap := w.gap(nil, nil)
 		dx := bounds.Dx() + gap
-		count := len(w.children)
 
+		j := 0
 		for i, v := range w.children {
-			x1 := bounds.Min.X + dx.Scale(i, count)
-			x2 := bounds.Min.X + dx.Scale(i+1, count) - gap
+			if isHiddenElement(v) {
+				x := bounds.Min.X
+				if count > 0 {
+					x += dx.Scale(j, count)
+				}
+				w.setBoundsForChild(i, v, x, bounds.Min.Y, x, bounds.Max.Y)
+				continue
+			}
+			x1 := bounds.Min.X + dx.Scale(j, count)
+			x2 := bounds.Min.X + dx.Scale(j+1, count) - gap
 			w.setBoundsForChild(i, v, x1, bounds.Min.Y, x2, bounds.Max.Y)
+			j++
 		}
 		return
 	}
@@ -252,13 +435,13 @@ func (w *hboxElement) SetBounds(bounds base.Rectangle) {
 		case MainEnd:
 			bounds.Min.X = bounds.Max.X - w.totalWidth
 		case SpaceAround:
-			extraGap = (bounds.Dx() - w.totalWidth).Scale(1, len(w.children)+1)
+			extraGap = (bounds.Dx() - w.totalWidth).Scale(1, count+1)
 			bounds.Min.X += extraGap
-			extraGap += calculateHGap(nil, nil)
+			extraGap += w.gap(nil, nil)
 		case SpaceBetween:
-			if len(w.children) > 1 {
-				extraGap = (bounds.Dx() - w.totalWidth).Scale(1, len(w.children)-1)
-				extraGap += calculateHGap(nil, nil)
+			if count > 1 {
+				extraGap = (bounds.Dx() - w.totalWidth).Scale(1, count-1)
+				extraGap += w.gap(nil, nil)
 			} else {
 				// There are no controls between which to put the extra space.
 				// The following essentially convert SpaceBetween to SpaceAround
@@ -271,9 +454,13 @@ func (w *hboxElement) SetBounds(bounds base.Rectangle) {
 	posX := bounds.Min.X
 	previous := base.Element(nil)
 	for i, v := range w.children {
+		if isHiddenElement(v) {
+			w.setBoundsForChild(i, v, posX, bounds.Min.Y, posX, bounds.Max.Y)
+			continue
+		}
 		if w.alignMain.IsPacked() {
-			if i > 0 {
-				posX += calculateHGap(previous, v)
+			if previous != nil {
+				posX += w.gap(previous, v)
 			}
 			previous = v
 		}
@@ -286,28 +473,37 @@ func (w *hboxElement) SetBounds(bounds base.Rectangle) {
 
 func (w *hboxElement) setBoundsForChild(i int, v base.Element, posX, posY, posX2, posY2 base.Length) {
 	dy := w.childrenInfo[i].size.Height
+	bounds := base.Rectangle{}
 	switch w.alignCross {
 	case CrossStart:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX, posY},
 			base.Point{posX2, posY + dy},
-		})
+		}
 	case CrossCenter:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX, posY + (posY2-posY-dy)/2},
 			base.Point{posX2, posY + (posY2-posY+dy)/2},
-		})
+		}
 	case CrossEnd:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX, posY2 - dy},
 			base.Point{posX2, posY2},
-		})
+		}
 	case Stretch:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX, posY},
 			base.Point{posX2, posY2},
-		})
+		}
+	case Baseline:
+		posY += w.baseline - w.childrenInfo[i].baseline
+		bounds = base.Rectangle{
+			base.Point{posX, posY},
+			base.Point{posX2, posY + dy},
+		}
 	}
+
+	v.SetBounds(mirrorForDirection(w.parent, w.bounds, bounds))
 }
 
 func updateFlex(c []base.Element, alignMain MainAxisAlign, clientInfo []boxElementInfo) ([]boxElementInfo, int) {
@@ -319,9 +515,15 @@ func updateFlex(c []base.Element, alignMain MainAxisAlign, clientInfo []boxEleme
 
 	totalFlex := 0
 	for i, v := range c {
+		clientInfo[i] = boxElementInfo{}
 		if elem, ok := v.(*expandElement); ok {
-			clientInfo[i].flex = elem.factor + 1
-			totalFlex += elem.factor + 1
+			if !elem.noGrow {
+				clientInfo[i].flex = elem.factor + 1
+				totalFlex += elem.factor + 1
+			}
+			if elem.shrink > 0 {
+				clientInfo[i].shrink = elem.shrink
+			}
 		}
 	}
 	if alignMain == Homogeneous {
@@ -330,13 +532,36 @@ func updateFlex(c []base.Element, alignMain MainAxisAlign, clientInfo []boxEleme
 	return clientInfo, totalFlex
 }
 
+func totalShrink(clientInfo []boxElementInfo) int {
+	total := 0
+	for _, v := range clientInfo {
+		total += v.shrink
+	}
+	return total
+}
+
+// shrinkableTotal returns the sum of the shrink factors for the children that
+// can still be reduced in size.
+func shrinkableTotal(clientInfo []boxElementInfo, canShrink func(int) bool) int {
+	total := 0
+	for i, v := range clientInfo {
+		if v.shrink > 0 && canShrink(i) {
+			total += v.shrink
+		}
+	}
+	return total
+}
+
 func (w *hboxElement) updateProps(data *HBox) (err error) {
 	// Update properties
 	w.alignMain = data.AlignMain
 	w.alignCross = data.AlignCross
+	w.spacing = data.Spacing
+	w.useSpacing = data.UseSpacing
 	w.children, err = base.DiffChildren(w.parent, w.children, data.Children)
 	// Clear cached values
 	w.childrenInfo, w.totalFlex = updateFlex(w.children, w.alignMain, w.childrenInfo)
+	w.cache.Invalidate()
 	w.totalWidth = 0
 	return err
 }
@@ -7,22 +7,6 @@ import (
 	"bitbucket.org/rj/goey/mock"
 )
 
-func (w *hboxElement) Props() base.Widget {
-	children := []base.Widget(nil)
-	if len(w.children) != 0 {
-		children = make([]base.Widget, 0, len(w.children))
-		for _, v := range w.children {
-			children = append(children, v.(Proper).Props())
-		}
-	}
-
-	return &HBox{
-		AlignMain:  w.alignMain,
-		AlignCross: w.alignCross,
-		Children:   children,
-	}
-}
-
 func TestHBoxMount(t *testing.T) {
 	buttons := []base.Widget{
 		&Button{Text: "A"},
@@ -38,6 +22,7 @@ func TestHBoxMount(t *testing.T) {
 		&HBox{Children: buttons, AlignMain: SpaceAround},
 		&HBox{Children: buttons, AlignMain: SpaceBetween},
 		&HBox{Children: buttons, AlignMain: Homogeneous},
+		&HBox{Children: buttons, Spacing: 4 * DIP, UseSpacing: true},
 	)
 }
 
@@ -77,11 +62,13 @@ func TestHBoxUpdateProps(t *testing.T) {
 		&HBox{Children: buttons, AlignMain: MainEnd, AlignCross: CrossStart},
 		&HBox{Children: widgets1},
 		&HBox{Children: widgets2},
+		&HBox{Children: buttons, AlignMain: MainEnd},
 	}, []base.Widget{
 		&HBox{Children: buttons, AlignMain: MainEnd},
 		&HBox{AlignMain: MainStart, AlignCross: CrossCenter},
 		&HBox{Children: widgets2},
 		&HBox{Children: widgets1},
+		&HBox{Children: buttons, AlignMain: MainEnd, Spacing: 4 * DIP, UseSpacing: true},
 	})
 }
 
@@ -171,3 +158,255 @@ func TestHBoxMinIntrinsic(t *testing.T) {
 		}
 	}
 }
+
+// testingShrinkElement is a mock element that can be laid out smaller than
+// its preferred size.
+type testingShrinkElement struct {
+	*mock.Element
+	min base.Size
+}
+
+func (w *testingShrinkElement) MinIntrinsicHeight(base.Length) base.Length {
+	return w.min.Height
+}
+
+func (w *testingShrinkElement) MinIntrinsicWidth(base.Length) base.Length {
+	return w.min.Width
+}
+
+func TestHBoxSpacing(t *testing.T) {
+	children := []base.Element{
+		mock.New(base.Size{26 * DIP, 13 * DIP}), mock.New(base.Size{13 * DIP, 11 * DIP})}
+
+	cases := []struct {
+		alignMain  MainAxisAlign
+		useSpacing bool
+		spacing    base.Length
+		minWidth   base.Length
+		bounds     []base.Rectangle
+	}{
+		{MainStart, false, 0, 50 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(37*DIP, 0, 50*DIP, 40*DIP),
+		}},
+		{MainStart, true, 0, 39 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(26*DIP, 0, 39*DIP, 40*DIP),
+		}},
+		{MainStart, true, 4 * DIP, 43 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(30*DIP, 0, 43*DIP, 40*DIP),
+		}},
+		{MainEnd, true, 4 * DIP, 43 * DIP, []base.Rectangle{
+			base.Rect(107*DIP, 0, 133*DIP, 40*DIP), base.Rect(137*DIP, 0, 150*DIP, 40*DIP),
+		}},
+		{Homogeneous, true, 10 * DIP, 62 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 70*DIP, 40*DIP), base.Rect(80*DIP, 0, 150*DIP, 40*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in := hboxElement{
+			children:     children,
+			alignMain:    v.alignMain,
+			spacing:      v.spacing,
+			useSpacing:   v.useSpacing,
+			childrenInfo: make([]boxElementInfo, len(children)),
+		}
+
+		if value := in.MinIntrinsicWidth(base.Inf); value != v.minWidth {
+			t.Errorf("Incorrect min intrinsic width on case %d, got %s, want %s", i, value, v.minWidth)
+		}
+		size := in.Layout(base.Tight(base.Size{150 * DIP, 40 * DIP}))
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+func TestHBoxHidden(t *testing.T) {
+	children := []base.Element{
+		mock.New(base.Size{26 * DIP, 10 * DIP}),
+		&visibilityElement{hidden: true, child: mock.New(base.Size{13 * DIP, 20 * DIP})},
+		mock.New(base.Size{13 * DIP, 20 * DIP}),
+	}
+
+	// The hidden child should not leave a gap between its siblings.
+	cases := []struct {
+		alignMain   MainAxisAlign
+		useSpacing  bool
+		spacing     base.Length
+		minWidth    base.Length
+		first, last base.Rectangle
+	}{
+		{MainStart, false, 0, 50 * DIP, base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(37*DIP, 0, 50*DIP, 40*DIP)},
+		{MainStart, true, 4 * DIP, 43 * DIP, base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(30*DIP, 0, 43*DIP, 40*DIP)},
+		{SpaceBetween, true, 4 * DIP, 43 * DIP, base.Rect(0, 0, 26*DIP, 40*DIP), base.Rect(137*DIP, 0, 150*DIP, 40*DIP)},
+		{Homogeneous, true, 4 * DIP, 56 * DIP, base.Rect(0, 0, 73*DIP, 40*DIP), base.Rect(77*DIP, 0, 150*DIP, 40*DIP)},
+	}
+
+	for i, v := range cases {
+		in := hboxElement{
+			children:     children,
+			alignMain:    v.alignMain,
+			spacing:      v.spacing,
+			useSpacing:   v.useSpacing,
+			childrenInfo: make([]boxElementInfo, len(children)),
+		}
+
+		if value := in.MinIntrinsicWidth(base.Inf); value != v.minWidth {
+			t.Errorf("Incorrect min intrinsic width on case %d, got %s, want %s", i, value, v.minWidth)
+		}
+		size := in.Layout(base.Tight(base.Size{150 * DIP, 40 * DIP}))
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		if got := children[0].Bounds(); got != v.first {
+			t.Errorf("Incorrect bounds for first child on case %d, got %s, want %s", i, got, v.first)
+		}
+		if got := children[2].Bounds(); got != v.last {
+			t.Errorf("Incorrect bounds for last child on case %d, got %s, want %s", i, got, v.last)
+		}
+	}
+}
+
+func TestHBoxShrink(t *testing.T) {
+	children := []base.Element{
+		&expandElement{
+			child:  &testingShrinkElement{mock.New(base.Size{100 * DIP, 10 * DIP}), base.Size{20 * DIP, 10 * DIP}},
+			noGrow: true,
+			shrink: 3,
+		},
+		mock.New(base.Size{50 * DIP, 10 * DIP}),
+		&expandElement{
+			child:  &testingShrinkElement{mock.New(base.Size{60 * DIP, 10 * DIP}), base.Size{30 * DIP, 10 * DIP}},
+			noGrow: true,
+			shrink: 1,
+		},
+	}
+
+	cases := []struct {
+		width  base.Length
+		bounds []base.Rectangle
+	}{
+		// The children fit, so they are not reduced.
+		{240 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 100*DIP, 10*DIP), base.Rect(110*DIP, 0, 160*DIP, 10*DIP), base.Rect(170*DIP, 0, 230*DIP, 10*DIP),
+		}},
+		// The overflow is divided according to the shrink factors.
+		{150 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 40*DIP, 10*DIP), base.Rect(50*DIP, 0, 100*DIP, 10*DIP), base.Rect(110*DIP, 0, 150*DIP, 10*DIP),
+		}},
+		// Once a child reaches its minimum size, the remaining overflow is
+		// taken from the other children.
+		{120 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 20*DIP, 10*DIP), base.Rect(30*DIP, 0, 80*DIP, 10*DIP), base.Rect(90*DIP, 0, 120*DIP, 10*DIP),
+		}},
+		// Children are not reduced below their minimum size.
+		{100 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 20*DIP, 10*DIP), base.Rect(30*DIP, 0, 80*DIP, 10*DIP), base.Rect(90*DIP, 0, 120*DIP, 10*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in := hboxElement{
+			children:   children,
+			alignMain:  MainStart,
+			alignCross: Stretch,
+			spacing:    10 * DIP,
+			useSpacing: true,
+		}
+		in.childrenInfo, in.totalFlex = updateFlex(in.children, in.alignMain, nil)
+
+		size := in.Layout(base.Tight(base.Size{v.width, 10 * DIP}))
+		if want := (base.Size{v.width, 10 * DIP}); size != want {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, want)
+		}
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+type testingBaselineElement struct {
+	*mock.Element
+	baseline base.Length
+}
+
+func (w *testingBaselineElement) Baseline(height base.Length) base.Length {
+	return w.baseline + (height-w.Size.Height)/2
+}
+
+func TestHBoxBaseline(t *testing.T) {
+	children := []base.Element{
+		&testingBaselineElement{mock.New(base.Size{20 * DIP, 23 * DIP}), 15 * DIP},
+		&testingBaselineElement{mock.New(base.Size{20 * DIP, 13 * DIP}), 10 * DIP},
+		// Elements without a baseline are aligned by their bottom edge.
+		mock.New(base.Size{20 * DIP, 30 * DIP}),
+	}
+	in := hboxElement{
+		children:     children,
+		alignMain:    MainStart,
+		alignCross:   Baseline,
+		childrenInfo: make([]boxElementInfo, len(children)),
+	}
+
+	if value := in.MinIntrinsicHeight(base.Inf); value != 38*DIP {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, 38*DIP)
+	}
+
+	size := in.Layout(base.Loose(base.Size{200 * DIP, 100 * DIP}))
+	if want := (base.Size{82 * DIP, 38 * DIP}); size != want {
+		t.Errorf("Incorrect size, got %s, want %s", size, want)
+	}
+	in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+
+	want := []base.Rectangle{
+		base.Rect(0, 15*DIP, 20*DIP, 38*DIP),
+		base.Rect(31*DIP, 20*DIP, 51*DIP, 33*DIP),
+		base.Rect(62*DIP, 0, 82*DIP, 30*DIP),
+	}
+	for i, v := range children {
+		if got := v.Bounds(); got != want[i] {
+			t.Errorf("Incorrect bounds for child %d, got %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestHBoxLayoutRightToLeft(t *testing.T) {
+	children := []base.Element{
+		mock.New(base.Size{26 * DIP, 13 * DIP}), mock.New(base.Size{13 * DIP, 11 * DIP})}
+	parent := base.Control{Context: (*base.Context)(nil).WithDirection(base.RightToLeft)}
+
+	cases := []struct {
+		alignMain  MainAxisAlign
+		alignCross CrossAxisAlign
+		bounds     []base.Rectangle
+	}{
+		{MainStart, Stretch, []base.Rectangle{
+			base.Rect(124*DIP, 0, 150*DIP, 40*DIP), base.Rect(100*DIP, 0, 113*DIP, 40*DIP),
+		}},
+		{MainEnd, CrossStart, []base.Rectangle{
+			base.Rect(24*DIP, 0, 50*DIP, 13*DIP), base.Rect(0, 0, 13*DIP, 11*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in := hboxElement{
+			parent:       parent,
+			children:     children,
+			alignMain:    v.alignMain,
+			alignCross:   v.alignCross,
+			childrenInfo: make([]boxElementInfo, len(children)),
+		}
+
+		size := in.Layout(base.Tight(base.Size{150 * DIP, 40 * DIP}))
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].(*mock.Element).Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type hrElement struct {
+	Control
+}
+
+func (w *HR) mount(parent base.Control) (base.Element, error) {
+	return &hrElement{}, nil
+}
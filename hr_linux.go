@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -17,7 +20,7 @@ func (w *HR) mount(parent base.Control) (base.Element, error) {
 	parent.Handle.Add(control)
 
 	retval := &hrElement{
-		Control: Control{&control.Widget},
+		Control: Control{handle: &control.Widget},
 	}
 
 	control.Connect("destroy", hrOnDestroy, retval)
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -59,7 +62,7 @@ func (w *HR) mount(parent base.Control) (base.Element, error) {
 		return nil, err
 	}
 
-	retval := &hrElement{Control: Control{hwnd}}
+	retval := &hrElement{Control: Control{hWnd: hwnd}}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
 
 	return retval, nil
@@ -11,6 +11,14 @@ type iconElement struct {
 	icon   rune
 }
 
+func (w *iconElement) Bounds() base.Rectangle {
+	return w.child.Bounds()
+}
+
+func (w *iconElement) Children() []base.Element {
+	return []base.Element{w.child}
+}
+
 func (w *iconElement) Close() {
 	w.child.Close()
 	w.child = nil
@@ -32,6 +40,10 @@ func (w *iconElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return w.child.MinIntrinsicWidth(height)
 }
 
+func (w *iconElement) Props() base.Widget {
+	return Icon(w.icon)
+}
+
 func (w *iconElement) SetBounds(bounds base.Rectangle) {
 	w.child.SetBounds(bounds)
 }
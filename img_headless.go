@@ -0,0 +1,51 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"image"
+	"image/draw"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+type imgElement struct {
+	Control
+
+	image  *image.RGBA
+	width  base.Length
+	height base.Length
+}
+
+func imageToRGBA(prop image.Image) *image.RGBA {
+	// Keep a copy of the pixel data, in the same way that the native
+	// controls copy the image into a bitmap.
+	bounds := prop.Bounds()
+	img := image.NewRGBA(bounds)
+	draw.Draw(img, bounds, prop, bounds.Min, draw.Src)
+	return img
+}
+
+func (w *Img) mount(parent base.Control) (base.Element, error) {
+	retval := &imgElement{
+		image:  imageToRGBA(w.Image),
+		width:  w.Width,
+		height: w.Height,
+	}
+	return retval, nil
+}
+
+func (w *imgElement) Props() base.Widget {
+	return &Img{
+		Image:  w.image,
+		Width:  w.width,
+		Height: w.height,
+	}
+}
+
+func (w *imgElement) updateProps(data *Img) error {
+	w.width, w.height = data.Width, data.Height
+	w.image = imageToRGBA(data.Image)
+	return nil
+}
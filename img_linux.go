@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -93,7 +96,7 @@ func (w *Img) mount(parent base.Control) (base.Element, error) {
 	parent.Handle.Add(handle)
 	handle.Show()
 
-	retval := &imgElement{Control{&handle.Widget}, buffer, w.Width, w.Height}
+	retval := &imgElement{Control{handle: &handle.Widget}, buffer, w.Width, w.Height}
 	handle.Connect("destroy", imgOnDestroy, retval)
 
 	return retval, nil
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -123,7 +126,7 @@ func (w *Img) mount(parent base.Control) (base.Element, error) {
 	win.SendMessage(hwnd, win2.STM_SETIMAGE, win.IMAGE_BITMAP, uintptr(hbitmap))
 
 	retval := &imgElement{
-		Control:   Control{hwnd},
+		Control:   Control{hWnd: hwnd},
 		imageData: buffer,
 		width:     w.Width,
 		height:    w.Height,
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 // Package syscall provides platform-dependent routines required to support the
 // package goey.
 // In particular, when using GTK+3, the goal is to fill in some missing APIs
@@ -68,6 +71,15 @@ func WidgetGetPreferredHeightForWidth(widget *gtk.Widget, width int) (int, int)
 	return int(minimum), int(natural)
 }
 
+// WidgetGetPreferredHeightAndBaselineForWidth is a wrapper around gtk_widget_get_preferred_height_and_baseline_for_width.
+func WidgetGetPreferredHeightAndBaselineForWidth(widget *gtk.Widget, width int) (int, int, int, int) {
+	var minimum, natural, minimumBaseline, naturalBaseline C.gint
+	p := unsafe.Pointer(widget.GObject)
+	C.gtk_widget_get_preferred_height_and_baseline_for_width((*C.GtkWidget)(p), C.gint(width),
+		&minimum, &natural, &minimumBaseline, &naturalBaseline)
+	return int(minimum), int(natural), int(minimumBaseline), int(naturalBaseline)
+}
+
 // WidgetSendKey is a wrapper around gtk_widget_event to send a key press and release event.
 func WidgetSendKey(widget *gtk.Widget, keyval rune, modifiers gdk.ModifierType, release uint8) {
 	p := unsafe.Pointer(widget.GObject)
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 // Package syscall provides platform-dependent routines required to support the
 // package goey.
 // In particular, on WIN32, the goal is to fill in some missing APIs that are not
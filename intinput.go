@@ -24,6 +24,7 @@ type IntInput struct {
 	OnFocus     func()            // OnFocus will be called whenever the field receives the keyboard focus
 	OnBlur      func()            // OnBlur will be called whenever the field loses the keyboard focus
 	OnEnterKey  func(value int64) // OnEnterKey will be called whenever the use hits the enter key
+	Key         string            // Key identifies the field when reconciling a list of children (see base.Keyed)
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -63,6 +64,16 @@ func (w *IntInput) UpdateValue() {
 	}
 }
 
+// GetKey returns the key for use in the Keyed interface.
+// Users should not need to use this method directly.
+func (w *IntInput) GetKey() string {
+	return w.Key
+}
+
+func (w *intinputElement) GetKey() string {
+	return w.key
+}
+
 func (*intinputElement) Kind() *base.Kind {
 	return &intInputKind
 }
@@ -0,0 +1,112 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"strconv"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+type intinputElement struct {
+	Control
+
+	text        string
+	value       int64
+	placeholder string
+	disabled    bool
+	min, max    int64
+	onChange    func(int64)
+	onEnterKey  func(int64)
+	key         string
+}
+
+func (w *IntInput) mount(parent base.Control) (base.Element, error) {
+	retval := &intinputElement{
+		key: w.Key,
+	}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *intinputElement) Baseline(height base.Length) base.Length {
+	return centeredBaseline(height)
+}
+
+func (w *intinputElement) Props() base.Widget {
+	return &IntInput{
+		Value:       w.value,
+		Placeholder: w.placeholder,
+		Disabled:    w.disabled,
+		Min:         w.min,
+		Max:         w.max,
+		OnChange:    w.onChange,
+		OnFocus:     w.onFocus,
+		OnBlur:      w.onBlur,
+		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
+	}
+}
+
+func (w *intinputElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *intinputElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 23 * DIP
+}
+
+func (w *intinputElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 75 * DIP
+}
+
+func (w *intinputElement) TypeKeys(text string) chan error {
+	return typeKeys(text, w.typeKey)
+}
+
+func (w *intinputElement) typeKey(r rune) {
+	if w.disabled || focusedControl != &w.Control {
+		return
+	}
+
+	if r == '\n' {
+		if w.onEnterKey != nil {
+			w.onEnterKey(w.value)
+		}
+		return
+	}
+
+	// The value set through the props is replaced by the first key typed, as
+	// if the contents of the field were selected.
+	text := w.text + string(r)
+	value, err := strconv.ParseInt(text, 10, 64)
+	if err != nil || value < w.min || value > w.max {
+		// Reject keys that do not lead to a valid value.
+		return
+	}
+	w.text = text
+	w.value = value
+	if w.onChange != nil {
+		w.onChange(value)
+	}
+}
+
+func (w *intinputElement) updateProps(data *IntInput) error {
+	w.text = ""
+	w.value = data.Value
+	w.placeholder = data.Placeholder
+	w.disabled = data.Disabled
+	w.min, w.max = data.Min, data.Max
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+	w.onEnterKey = data.OnEnterKey
+
+	return nil
+}
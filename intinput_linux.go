@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -18,6 +21,7 @@ type intinputElement struct {
 	onBlur     blurSlot
 	onEnterKey func(int64)
 	shEnterKey glib.SignalHandle
+	key        string
 }
 
 func (w *IntInput) mount(parent base.Control) (base.Element, error) {
@@ -37,9 +41,10 @@ func (w *IntInput) mount(parent base.Control) (base.Element, error) {
 
 	// Create the element
 	retval := &intinputElement{
-		Control:    Control{&control.Widget},
+		Control:    Control{handle: &control.Widget},
 		onChange:   w.OnChange,
 		onEnterKey: w.OnEnterKey,
+		key:        w.Key,
 	}
 
 	// Connect all callbacks for the events
@@ -81,6 +86,10 @@ func intinputOnDestroy(widget *gtk.SpinButton, mounted *intinputElement) {
 	mounted.handle = nil
 }
 
+func (w *intinputElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *intinputElement) spinbutton() *gtk.SpinButton {
 	return (*gtk.SpinButton)(unsafe.Pointer(w.handle))
 }
@@ -119,6 +128,7 @@ func (w *intinputElement) Props() base.Widget {
 		OnFocus:     w.onFocus.callback,
 		OnBlur:      w.onBlur.callback,
 		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
 	}
 }
 
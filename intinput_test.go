@@ -45,7 +45,7 @@ func TestIntInputOnChange(t *testing.T) {
 		}})
 
 	want := []int64{1, 12, 123, 1234}
-	if runtime.GOOS == "linux" {
+	if runtime.GOOS == "linux" && !testingHeadless {
 		// Control does not output events for intermediate typing.
 		want = []int64{1234}
 	}
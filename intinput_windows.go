@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -78,7 +81,7 @@ func (w *IntInput) mount(parent base.Control) (base.Element, error) {
 
 	// Create the return value.
 	retval := &intinputElement{
-		Control:    Control{hwnd},
+		Control:    Control{hWnd: hwnd},
 		hwndUpDown: hwndUpDown,
 		min:        w.Min,
 		max:        w.Max,
@@ -86,6 +89,7 @@ func (w *IntInput) mount(parent base.Control) (base.Element, error) {
 		onFocus:    w.OnFocus,
 		onBlur:     w.OnBlur,
 		onEnterKey: w.OnEnterKey,
+		key:        w.Key,
 	}
 
 	// Link the control back to Go for event handling
@@ -117,6 +121,11 @@ type intinputElement struct {
 	onFocus    func()
 	onBlur     func()
 	onEnterKey func(int64)
+	key        string
+}
+
+func (w *intinputElement) Baseline(height base.Length) base.Length {
+	return w.centeredBaseline(height)
 }
 
 func (w *intinputElement) Close() {
@@ -209,10 +218,13 @@ func (w *intinputElement) Props() base.Widget {
 		OnFocus:     w.onFocus,
 		OnBlur:      w.onBlur,
 		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
 	}
 }
 
 func (w *intinputElement) SetBounds(bounds base.Rectangle) {
+	// The bounds include the up-down control, if present.
+	w.bounds = bounds
 	buddyWidth := (23 * DIP) * 2 / 3
 
 	if w.hwndUpDown == 0 {
@@ -230,6 +242,15 @@ func (w *intinputElement) SetBounds(bounds base.Rectangle) {
 	}
 }
 
+func (w *intinputElement) setHidden(value bool) {
+	w.Control.setHidden(value)
+	// The up-down control is only shown if there is enough space, so showing
+	// it again is left to SetBounds.
+	if value && w.hwndUpDown != 0 {
+		win.ShowWindow(w.hwndUpDown, win.SW_HIDE)
+	}
+}
+
 func (w *intinputElement) SetOrder(previous win.HWND) win.HWND {
 	if w.hwndUpDown != 0 {
 		win.SetWindowPos(w.hwndUpDown, previous, 0, 0, 0, 0, win.SWP_NOMOVE|win.SWP_NOSIZE|win.SWP_NOREDRAW|0x400)
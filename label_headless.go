@@ -0,0 +1,50 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type labelElement struct {
+	Control
+
+	text          string
+	mnemonicField base.Element // Field that receives focus for the mnemonic.
+}
+
+func (w *Label) mount(parent base.Control) (base.Element, error) {
+	retval := &labelElement{text: w.Text}
+	return retval, nil
+}
+
+func (w *labelElement) Baseline(height base.Length) base.Length {
+	return textBaseline
+}
+
+func (w *labelElement) Props() base.Widget {
+	return &Label{
+		Text: w.text,
+	}
+}
+
+func (w *labelElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *labelElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return measureText(w.text).Height
+}
+
+func (w *labelElement) MinIntrinsicWidth(base.Length) base.Length {
+	return measureText(w.text).Width
+}
+
+func (w *labelElement) updateProps(data *Label) error {
+	w.text = data.Text
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -23,7 +26,7 @@ func (w *Label) mount(parent base.Control) (base.Element, error) {
 	handle.SetLineWrap(false)
 	handle.Show()
 
-	retval := &labelElement{Control: Control{&handle.Widget}}
+	retval := &labelElement{Control: Control{handle: &handle.Widget}}
 	handle.Connect("destroy", labelOnDestroy, retval)
 
 	return retval, nil
@@ -33,6 +36,10 @@ func labelOnDestroy(widget *gtk.Label, mounted *labelElement) {
 	mounted.handle = nil
 }
 
+func (w *labelElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *labelElement) label() *gtk.Label {
 	return (*gtk.Label)(unsafe.Pointer(w.handle))
 }
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -25,7 +28,7 @@ func (w *Label) mount(parent base.Control) (base.Element, error) {
 		return nil, err
 	}
 
-	retval := &labelElement{Control: Control{hwnd}, text: text}
+	retval := &labelElement{Control: Control{hWnd: hwnd}, text: text}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
 
 	return retval, nil
@@ -36,6 +39,12 @@ type labelElement struct {
 	text []uint16
 }
 
+func (w *labelElement) Baseline(height base.Length) base.Length {
+	// Static text is drawn at the top of the control.
+	ascent, _ := w.textMetrics()
+	return ascent
+}
+
 func (w *labelElement) Props() base.Widget {
 	return &Label{
 		Text: w.Control.Text(),
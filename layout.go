@@ -4,6 +4,10 @@ import (
 	"bitbucket.org/rj/goey/base"
 )
 
+// labelGap is the space between a label and its associated control, whether
+// the label is placed above or beside the control.
+const labelGap = 5 * DIP
+
 func calculateHGap(previous base.Element, current base.Element) base.Length {
 	// The vertical gap between most controls is 11 relative pixels.  However,
 	// there are different rules for between a label and its associated control,
@@ -45,7 +49,7 @@ func calculateVGap(previous base.Element, current base.Element) base.Length {
 	if _, ok := previous.(*labelElement); ok {
 		// Any label immediately preceding any other control will be assumed to
 		// be 'associated'.
-		return 5 * DIP
+		return labelGap
 	}
 	if _, ok := previous.(*checkboxElement); ok {
 		if _, ok := current.(*checkboxElement); ok {
@@ -59,3 +63,16 @@ func calculateVGap(previous base.Element, current base.Element) base.Length {
 	// between paragraphs of text.
 	return 11 * DIP
 }
+
+// mirrorForDirection reflects the bounds for a child horizontally within the
+// bounds of its container, if the layout direction is right-to-left.
+// Containers can position their children as if the layout were left-to-right,
+// and then use this function to adjust the final bounds.
+func mirrorForDirection(parent base.Control, container, child base.Rectangle) base.Rectangle {
+	if parent.Context.Direction() != base.RightToLeft {
+		return child
+	}
+
+	child.Min.X, child.Max.X = container.Min.X+container.Max.X-child.Max.X, container.Min.X+container.Max.X-child.Min.X
+	return child
+}
@@ -0,0 +1,173 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+)
+
+var (
+	layoutBuilderKind = base.NewKind("bitbucket.org/rj/goey.LayoutBuilder")
+)
+
+// LayoutBuilder describes a widget that creates its child widget based on the
+// space available.  This allows an application to switch between layouts,
+// such as using two columns when the window is wide, but only a single column
+// when the window is narrow.
+//
+// The function Build receives the constraints from the parent's final layout
+// of the widget, which is the layout used to position it.  Parents may lay out
+// the widget several times while measuring, so the widget is not rebuilt
+// until its position is set, once the layout pass has completed.  Until then,
+// the existing child is laid out with the new constraints.  If the rebuilt
+// child has a different size, the window's layout is updated again.
+//
+// When the widget is mounted, Build is called with unbounded constraints.
+// Build is called again whenever the final constraints change, or when the
+// widget is updated, in which case it receives the same constraints as for
+// the previous call.
+//
+// Errors cannot be returned during layout.  If the child cannot be updated,
+// the previous child is kept, and the error is passed to OnError, if set.
+type LayoutBuilder struct {
+	Build   func(base.Constraints) base.Widget // Create the child widget for the constraints.
+	OnError func(error)                        // OnError will be called if the child cannot be updated during layout.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*LayoutBuilder) Kind() *base.Kind {
+	return &layoutBuilderKind
+}
+
+// Mount creates the child widget returned by Build in the GUI.  The newly
+// created widget will be a child of the widget specified by parent.
+func (w *LayoutBuilder) Mount(parent base.Control) (base.Element, error) {
+	bc := base.Expand()
+	child, err := base.Mount(parent, w.Build(bc))
+	if child == nil {
+		return nil, err
+	}
+
+	return &layoutBuilderElement{
+		parent:      parent,
+		child:       child,
+		build:       w.Build,
+		onError:     w.OnError,
+		constraints: bc,
+		pending:     bc,
+	}, err
+}
+
+type layoutBuilderElement struct {
+	parent  base.Control
+	child   base.Element
+	build   func(base.Constraints) base.Widget
+	onError func(error)
+
+	constraints base.Constraints // Constraints used for the last call to build.
+	pending     base.Constraints // Constraints from the most recent layout.
+	relayout    bool             // Set if a layout of the window has been requested.
+	bounds      base.Rectangle
+	order       layoutBuilderOrder
+}
+
+func (w *layoutBuilderElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *layoutBuilderElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *layoutBuilderElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*layoutBuilderElement) Kind() *base.Kind {
+	return &layoutBuilderKind
+}
+
+func (w *layoutBuilderElement) Layout(bc base.Constraints) base.Size {
+	// Rebuilding the child is deferred until SetBounds, as reconciling the
+	// child would discard the cached layout for the entire window.
+	w.pending = bc
+	return base.Layout(w.child, bc)
+}
+
+func (w *layoutBuilderElement) MinIntrinsicHeight(width base.Length) base.Length {
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *layoutBuilderElement) MinIntrinsicWidth(height base.Length) base.Length {
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *layoutBuilderElement) Props() base.Widget {
+	return &LayoutBuilder{
+		Build:   w.build,
+		OnError: w.onError,
+	}
+}
+
+// rebuild calls build, and then reconciles the child with the returned
+// widget.
+func (w *layoutBuilderElement) rebuild(bc base.Constraints) (err error) {
+	w.constraints = bc
+	w.child, err = base.DiffChild(w.parent, w.child, w.build(bc))
+	hideNewElements(w.parent, w.child)
+	w.updateOrder()
+	return err
+}
+
+func (w *layoutBuilderElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	if w.pending != w.constraints {
+		if err := w.rebuild(w.pending); err != nil && w.onError != nil {
+			w.onError(err)
+		}
+		// The new child must be laid out before it can be positioned.  If its
+		// size does not match the space allocated for the old child, the
+		// parent needs to redo its layout.
+		size := base.Layout(w.child, w.constraints)
+		if size != (base.Size{bounds.Dx(), bounds.Dy()}) {
+			w.requestLayout()
+		}
+	}
+	w.child.SetBounds(bounds)
+}
+
+// requestLayout arranges for the layout of the window to be updated once the
+// current layout has completed.
+func (w *layoutBuilderElement) requestLayout() {
+	if w.relayout {
+		return
+	}
+
+	w.relayout = true
+	go loop.Do(func() error {
+		w.relayout = false
+		// The element may have been closed in the meantime.
+		if w.child != nil {
+			requestLayout(w.parent)
+		}
+		return nil
+	})
+}
+
+func (w *layoutBuilderElement) updateProps(data *LayoutBuilder) error {
+	// The build function may have captured new values from the parent, so the
+	// child needs to be rebuilt.
+	w.build = data.Build
+	w.onError = data.OnError
+	return w.rebuild(w.constraints)
+}
+
+func (w *layoutBuilderElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*LayoutBuilder))
+}
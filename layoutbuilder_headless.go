@@ -0,0 +1,12 @@
+//go:build headless
+// +build headless
+
+package goey
+
+// layoutBuilderOrder is not required, as controls do not need to be
+// reordered after they are mounted.
+type layoutBuilderOrder struct{}
+
+func (w *layoutBuilderElement) updateOrder() {
+	// Nothing required.
+}
@@ -0,0 +1,96 @@
+package goey
+
+import (
+	"errors"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func ExampleLayoutBuilder() {
+	fields := []base.Widget{
+		&TextInput{Placeholder: "First name"},
+		&TextInput{Placeholder: "Last name"},
+	}
+
+	// When the window is wide, the fields are placed side by side.
+	// Otherwise, they are placed in a single column.
+	_ = &LayoutBuilder{
+		Build: func(bc base.Constraints) base.Widget {
+			if bc.Max.Width >= 400*DIP {
+				return &HBox{Children: fields}
+			}
+			return &VBox{Children: fields}
+		},
+	}
+}
+
+func TestLayoutBuilderClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&LayoutBuilder{Build: func(base.Constraints) base.Widget { return &Button{Text: "A"} }},
+		&LayoutBuilder{Build: func(base.Constraints) base.Widget { return nil }},
+	)
+}
+
+func TestLayoutBuilderLayout(t *testing.T) {
+	mockErr := errors.New("Mock error")
+	calls := []base.Constraints{}
+	build := func(bc base.Constraints) base.Widget {
+		calls = append(calls, bc)
+		switch {
+		case bc.Max.Width < 10*DIP:
+			return &mock.Widget{Key: "fail", Err: mockErr}
+		case bc.Max.Width < 100*DIP:
+			return &mock.Widget{Key: "narrow", Size: base.Size{10 * DIP, 20 * DIP}}
+		}
+		return &mock.Widget{Key: "wide", Size: base.Size{20 * DIP, 10 * DIP}}
+	}
+	errs := []error{}
+
+	elem, err := (&LayoutBuilder{
+		Build:   build,
+		OnError: func(err error) { errs = append(errs, err) },
+	}).Mount(base.Control{})
+	if err != nil {
+		t.Fatalf("Failed to mount, %s", err)
+	}
+	in := elem.(*layoutBuilderElement)
+	if key := base.KeyOf(in.child); key != "wide" {
+		t.Errorf("Incorrect child after mount, got %q, want %q", key, "wide")
+	}
+
+	// The child is not rebuilt while measuring, but only once the bounds are
+	// set, and only when the constraints change.
+	narrow := base.Loose(base.Size{50 * DIP, 50 * DIP})
+	in.Layout(base.Loose(base.Size{70 * DIP, 50 * DIP}))
+	in.Layout(narrow)
+	if key := base.KeyOf(in.child); key != "wide" {
+		t.Errorf("Incorrect child during layout, got %q, want %q", key, "wide")
+	}
+	for i := 0; i < 2; i++ {
+		in.SetBounds(base.Rect(0, 0, 10*DIP, 20*DIP))
+		in.Layout(narrow)
+	}
+	if key := base.KeyOf(in.child); key != "narrow" {
+		t.Errorf("Incorrect child after layout, got %q, want %q", key, "narrow")
+	}
+	if size := in.Layout(narrow); size != (base.Size{10 * DIP, 20 * DIP}) {
+		t.Errorf("Incorrect size, got %s", size)
+	}
+	if len(calls) != 2 || calls[1] != narrow {
+		t.Errorf("Incorrect calls to build, got %v", calls)
+	}
+
+	// If the new child cannot be mounted, the previous child is kept.
+	in.Layout(base.Loose(base.Size{5 * DIP, 50 * DIP}))
+	in.SetBounds(base.Rect(0, 0, 10*DIP, 20*DIP))
+	if key := base.KeyOf(in.child); key != "narrow" {
+		t.Errorf("Incorrect child after error, got %q, want %q", key, "narrow")
+	}
+	if len(errs) != 1 || errs[0] != mockErr {
+		t.Errorf("Incorrect calls to OnError, got %v", errs)
+	}
+
+	in.Close()
+}
@@ -0,0 +1,29 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"github.com/lxn/win"
+)
+
+// layoutBuilderOrder records the position of the child in the tab order, so
+// that controls mounted during layout can be placed correctly.
+type layoutBuilderOrder struct {
+	previous win.HWND
+	valid    bool
+}
+
+func (w *layoutBuilderElement) SetOrder(previous win.HWND) win.HWND {
+	w.order = layoutBuilderOrder{previous: previous, valid: true}
+	return w.child.SetOrder(previous)
+}
+
+func (w *layoutBuilderElement) updateOrder() {
+	// New controls are placed at the end of the tab order when they are
+	// created.  Unless the window has not yet ordered the children, they need
+	// to be moved.
+	if w.order.valid {
+		w.child.SetOrder(w.order.previous)
+	}
+}
@@ -0,0 +1,221 @@
+package goey
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+// layoutDebug holds the settings used to diagnose problems with layout.  The
+// settings are read from the environment variable GOEY_LAYOUT_DEBUG, which
+// contains a comma separated list of options.  The option 'text' or 'json'
+// selects the format used to dump the element tree after every layout of the
+// window.  The option 'outline' draws the bounds of every element over the
+// window, on platforms where that is supported.
+//
+// For example, GOEY_LAYOUT_DEBUG=json,outline will dump the tree as JSON, and
+// draw the outlines.
+type layoutDebug struct {
+	format  string    // Either empty, "text", or "json".
+	outline bool      // Draw the bounds of elements over the window.
+	out     io.Writer // Destination for the dumps.
+}
+
+// layoutRecord holds the constraints and size from the most recent layout of
+// an element.
+type layoutRecord struct {
+	constraints base.Constraints
+	size        base.Size
+}
+
+// layoutRecords holds the most recent layout for every element, once
+// recording has been started.  The map is only accessed from the GUI thread.
+var layoutRecords map[base.Element]layoutRecord
+
+// startLayoutRecording arranges for the constraints and size from every
+// layout to be recorded, so that they can be included in the dumps.
+func startLayoutRecording() {
+	if layoutRecords != nil {
+		return
+	}
+
+	layoutRecords = make(map[base.Element]layoutRecord)
+	base.SetLayoutObserver(func(elem base.Element, bc base.Constraints, size base.Size) {
+		layoutRecords[elem] = layoutRecord{bc, size}
+	})
+}
+
+func layoutDebugDefaults() layoutDebug {
+	env := os.Getenv("GOEY_LAYOUT_DEBUG")
+	if env == "" {
+		return layoutDebug{}
+	}
+
+	retval := layoutDebug{out: os.Stderr}
+	for _, v := range strings.Split(env, ",") {
+		switch v = strings.TrimSpace(v); v {
+		case "1", "text":
+			retval.format = "text"
+		case "json":
+			retval.format = "json"
+		case "outline":
+			retval.outline = true
+		default:
+			fmt.Fprintln(os.Stderr, "error: GOEY_LAYOUT_DEBUG: Unrecognized option", v)
+		}
+	}
+	if retval.format != "" {
+		startLayoutRecording()
+	}
+	return retval
+}
+
+// record checks the constraints and size used for layout of the window's
+// child.  If layout debugging is enabled, and the constraints are not
+// satisfied, a message is written.  When dumps are enabled, the violation is
+// instead reported as part of the dump.
+func (d *layoutDebug) record(constraints base.Constraints, size base.Size) {
+	if d.out == nil || d.format != "" {
+		return
+	}
+	if !constraints.IsSatisfiedBy(size) {
+		fmt.Fprintln(d.out, "constraints not satisfied,", constraints, ",", size)
+	}
+}
+
+// dump writes a description of the element tree, if enabled.  This should be
+// called once the bounds for all of the elements have been set.
+func (d *layoutDebug) dump(child base.Element) {
+	if d.format == "" || child == nil {
+		return
+	}
+
+	visited := make(map[base.Element]bool, len(layoutRecords))
+	node := newLayoutDebugNode(child, visited)
+	err := writeLayoutDebug(d.out, d.format, node)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error: GOEY_LAYOUT_DEBUG:", err.Error())
+	}
+
+	// Keep only the records for elements in the tree, so that closed elements
+	// are not kept alive.
+	for k := range layoutRecords {
+		if !visited[k] {
+			delete(layoutRecords, k)
+		}
+	}
+}
+
+// debugLength is a length that is encoded as a number of DIPs in JSON.
+// Unbounded lengths are encoded as null.
+type debugLength base.Length
+
+func (v debugLength) MarshalJSON() ([]byte, error) {
+	if base.Length(v) == base.Inf {
+		return []byte("null"), nil
+	}
+	return json.Marshal(base.Length(v).DIP())
+}
+
+type debugSize struct {
+	Width  debugLength `json:"width"`
+	Height debugLength `json:"height"`
+}
+
+type debugConstraints struct {
+	Min debugSize `json:"min"`
+	Max debugSize `json:"max"`
+}
+
+type layoutDebugNode struct {
+	Kind        string             `json:"kind"`
+	Bounds      [4]debugLength     `json:"bounds"`
+	MinSize     debugSize          `json:"minSize"`
+	Constraints *debugConstraints  `json:"constraints,omitempty"`
+	Size        *debugSize         `json:"size,omitempty"`
+	Satisfied   *bool              `json:"satisfied,omitempty"`
+	Children    []*layoutDebugNode `json:"children,omitempty"`
+}
+
+func newDebugSize(size base.Size) debugSize {
+	return debugSize{debugLength(size.Width), debugLength(size.Height)}
+}
+
+func (s debugSize) String() string {
+	return s.Width.String() + "x" + s.Height.String()
+}
+
+func (v debugLength) String() string {
+	if base.Length(v) == base.Inf {
+		return "inf"
+	}
+	return base.Length(v).String()
+}
+
+// newLayoutDebugNode describes the element and its descendants.  Every
+// element visited is added to the map visited.
+func newLayoutDebugNode(elem base.Element, visited map[base.Element]bool) *layoutDebugNode {
+	bounds := elem.Bounds()
+	node := &layoutDebugNode{
+		Kind: elem.Kind().String(),
+		Bounds: [4]debugLength{
+			debugLength(bounds.Min.X), debugLength(bounds.Min.Y),
+			debugLength(bounds.Max.X), debugLength(bounds.Max.Y),
+		},
+		MinSize: debugSize{
+			debugLength(elem.MinIntrinsicWidth(base.Inf)),
+			debugLength(elem.MinIntrinsicHeight(base.Inf)),
+		},
+	}
+	if record, ok := layoutRecords[elem]; ok {
+		node.setLayout(record.constraints, record.size)
+	}
+	visited[elem] = true
+
+	for _, v := range base.ChildrenOf(elem) {
+		node.Children = append(node.Children, newLayoutDebugNode(v, visited))
+	}
+	return node
+}
+
+func (n *layoutDebugNode) setLayout(bc base.Constraints, size base.Size) {
+	n.Constraints = &debugConstraints{newDebugSize(bc.Min), newDebugSize(bc.Max)}
+	s := newDebugSize(size)
+	n.Size = &s
+	satisfied := bc.IsSatisfiedBy(size)
+	n.Satisfied = &satisfied
+}
+
+func writeLayoutDebug(out io.Writer, format string, node *layoutDebugNode) error {
+	if format == "json" {
+		return json.NewEncoder(out).Encode(node)
+	}
+	return writeLayoutDebugText(out, node, "")
+}
+
+func writeLayoutDebugText(out io.Writer, node *layoutDebugNode, indent string) error {
+	line := fmt.Sprintf("%s%s bounds=(%s,%s)-(%s,%s) min=%s",
+		indent, node.Kind,
+		node.Bounds[0], node.Bounds[1], node.Bounds[2], node.Bounds[3],
+		node.MinSize)
+	if node.Constraints != nil {
+		line += fmt.Sprintf(" constraints=%s-%s size=%s", node.Constraints.Min, node.Constraints.Max, node.Size)
+		if !*node.Satisfied {
+			line += " (not satisfied)"
+		}
+	}
+	if _, err := io.WriteString(out, line+"\n"); err != nil {
+		return err
+	}
+
+	for _, v := range node.Children {
+		if err := writeLayoutDebugText(out, v, indent+"  "); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package goey
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestLayoutDebugDefaults(t *testing.T) {
+	defer os.Setenv("GOEY_LAYOUT_DEBUG", os.Getenv("GOEY_LAYOUT_DEBUG"))
+
+	cases := []struct {
+		env     string
+		format  string
+		outline bool
+	}{
+		{"", "", false},
+		{"1", "text", false},
+		{"text", "text", false},
+		{"json", "json", false},
+		{"outline", "", true},
+		{"json, outline", "json", true},
+	}
+
+	for i, v := range cases {
+		os.Setenv("GOEY_LAYOUT_DEBUG", v.env)
+		out := layoutDebugDefaults()
+		if out.format != v.format {
+			t.Errorf("Case %d: Incorrect format, got %q, want %q", i, out.format, v.format)
+		}
+		if out.outline != v.outline {
+			t.Errorf("Case %d: Incorrect outline, got %v, want %v", i, out.outline, v.outline)
+		}
+	}
+}
+
+func TestLayoutDebugRecord(t *testing.T) {
+	bc := base.Tight(base.Size{100 * DIP, 50 * DIP})
+	size := base.Size{120 * DIP, 50 * DIP}
+
+	cases := []struct {
+		format string
+		out    bool
+		want   string
+	}{
+		{"", false, ""},
+		{"", true, fmt.Sprintln("constraints not satisfied,", bc, ",", size)},
+		{"text", true, ""},
+		{"json", true, ""},
+	}
+
+	for i, v := range cases {
+		out := bytes.Buffer{}
+		d := layoutDebug{format: v.format}
+		if v.out {
+			d.out = &out
+		}
+		d.record(bc, size)
+		d.record(bc, bc.Max)
+		if got := out.String(); got != v.want {
+			t.Errorf("Case %d: Incorrect output, got %q, want %q", i, got, v.want)
+		}
+	}
+}
+
+func TestLayoutDebugDump(t *testing.T) {
+	children := []base.Element{
+		mock.New(base.Size{26 * DIP, 13 * DIP}), mock.New(base.Size{13 * DIP, 11 * DIP}),
+	}
+	ci, totalFlex := updateFlex(children, MainStart, nil)
+	elem := &vboxElement{
+		children:     children,
+		childrenInfo: ci,
+		totalFlex:    totalFlex,
+	}
+
+	startLayoutRecording()
+	defer func() {
+		base.SetLayoutObserver(nil)
+		layoutRecords = nil
+	}()
+	bc := base.Tight(base.Size{100 * DIP, 50 * DIP})
+	size := base.Layout(elem, bc)
+	elem.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+
+	t.Run("text", func(t *testing.T) {
+		out := bytes.Buffer{}
+		d := layoutDebug{format: "text", out: &out}
+		d.record(bc, size)
+		d.dump(elem)
+
+		lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("Incorrect number of lines, got %d, want %d", len(lines), 3)
+		}
+		want := "bitbucket.org/rj/goey.VBox bounds=(0:00,0:00)-(100:00,50:00) min=26:00x35:00 constraints=100:00x50:00-100:00x50:00 size=100:00x50:00"
+		if lines[0] != want {
+			t.Errorf("Incorrect line for root, got %q, want %q", lines[0], want)
+		}
+		if !strings.HasPrefix(lines[1], "  "+mock.New(base.Size{}).Kind().String()+" ") {
+			t.Errorf("Incorrect line for child, got %q", lines[1])
+		}
+		// Constraints and sizes are included for leaf elements.
+		if !strings.Contains(lines[1], " constraints=") || !strings.Contains(lines[1], " size=") {
+			t.Errorf("Missing layout for child, got %q", lines[1])
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := bytes.Buffer{}
+		d := layoutDebug{format: "json", out: &out}
+		d.record(bc, size)
+		d.dump(elem)
+
+		node := struct {
+			Kind     string
+			Bounds   []float64
+			Children []struct {
+				Kind    string
+				MinSize struct{ Width, Height float64 }
+			}
+		}{}
+		if err := json.Unmarshal(out.Bytes(), &node); err != nil {
+			t.Fatalf("Failed to decode JSON, %s", err)
+		}
+		if node.Kind != "bitbucket.org/rj/goey.VBox" {
+			t.Errorf("Incorrect kind, got %s", node.Kind)
+		}
+		if len(node.Bounds) != 4 || node.Bounds[2] != 100 || node.Bounds[3] != 50 {
+			t.Errorf("Incorrect bounds, got %v", node.Bounds)
+		}
+		if len(node.Children) != 2 || node.Children[0].MinSize.Width != 26 {
+			t.Errorf("Incorrect children, got %v", node.Children)
+		}
+	})
+}
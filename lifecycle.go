@@ -0,0 +1,151 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	lifecycleKind = base.NewKind("bitbucket.org/rj/goey.Lifecycle")
+)
+
+// Lifecycle describes a widget that notifies the application when its child
+// is mounted, updated, or closed.  This can be used to start work when a
+// widget first appears, such as a goroutine feeding a progress bar, or to
+// release resources when the widget is removed from the GUI.
+//
+// All of the callbacks are optional, and are called on the GUI thread.
+// OnMount is called after the child has been mounted, and OnUpdate is called
+// after the child has been updated with new properties.  OnClose is called
+// before the child is closed, so the element passed to OnMount or OnUpdate is
+// still valid.
+//
+// If the child has a key, the lifecycle uses the same key, so the child can
+// be wrapped without changing how it is matched amongst its siblings.
+type Lifecycle struct {
+	OnMount  func(base.Element) // Called after the child is mounted.
+	OnUpdate func(base.Element) // Called after the child is updated.
+	OnClose  func()             // Called before the child is closed.
+	Child    base.Widget        // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Lifecycle) Kind() *base.Kind {
+	return &lifecycleKind
+}
+
+// GetKey returns the key of the child widget.
+func (w *Lifecycle) GetKey() string {
+	return base.KeyOf(w.Child)
+}
+
+// Mount creates the child widget in the GUI, and then calls OnMount.  The
+// newly created widget will be a child of the widget specified by parent.
+func (w *Lifecycle) Mount(parent base.Control) (base.Element, error) {
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	retval := &lifecycleElement{
+		parent: parent,
+		child:  child,
+	}
+	retval.setHooks(w)
+
+	if w.OnMount != nil {
+		w.OnMount(child)
+	}
+	return retval, err
+}
+
+type lifecycleElement struct {
+	parent   base.Control
+	child    base.Element
+	onMount  func(base.Element)
+	onUpdate func(base.Element)
+	onClose  func()
+	bounds   base.Rectangle
+}
+
+func (w *lifecycleElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *lifecycleElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *lifecycleElement) Close() {
+	if w.onClose != nil {
+		w.onClose()
+		w.onClose = nil
+	}
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (w *lifecycleElement) GetKey() string {
+	return base.KeyOf(w.child)
+}
+
+func (*lifecycleElement) Kind() *base.Kind {
+	return &lifecycleKind
+}
+
+func (w *lifecycleElement) Layout(bc base.Constraints) base.Size {
+	return base.Layout(w.child, bc)
+}
+
+func (w *lifecycleElement) MinIntrinsicHeight(width base.Length) base.Length {
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *lifecycleElement) MinIntrinsicWidth(height base.Length) base.Length {
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *lifecycleElement) Props() base.Widget {
+	return &Lifecycle{
+		OnMount:  w.onMount,
+		OnUpdate: w.onUpdate,
+		OnClose:  w.onClose,
+		Child:    base.PropsOf(w.child),
+	}
+}
+
+func (w *lifecycleElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	w.child.SetBounds(bounds)
+}
+
+func (w *lifecycleElement) setHooks(data *Lifecycle) {
+	w.onMount = data.OnMount
+	w.onUpdate = data.OnUpdate
+	w.onClose = data.OnClose
+}
+
+func (w *lifecycleElement) updateProps(data *Lifecycle) (err error) {
+	// The callbacks may have captured new values, so the new callbacks are
+	// used from now on, including when the element is closed.
+	w.setHooks(data)
+
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	if err != nil {
+		return err
+	}
+
+	if w.onUpdate != nil {
+		w.onUpdate(w.child)
+	}
+	return nil
+}
+
+func (w *lifecycleElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Lifecycle))
+}
@@ -0,0 +1,91 @@
+package goey
+
+import (
+	"reflect"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+)
+
+func TestLifecycleMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Lifecycle{Child: &Button{Text: "A"}},
+		&Lifecycle{Child: &Label{Text: "B"}},
+		&Lifecycle{},
+	)
+}
+
+func TestLifecycleClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Lifecycle{Child: &Button{Text: "A"}},
+		&Lifecycle{OnClose: func() {}, Child: &Label{Text: "B"}},
+		&Lifecycle{},
+	)
+}
+
+func TestLifecycleUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Lifecycle{Child: &Button{Text: "A"}},
+		&Lifecycle{Child: &Label{Text: "B"}},
+	}, []base.Widget{
+		&Lifecycle{Child: &Button{Text: "AB"}},
+		&Lifecycle{Child: &Label{Text: "BC"}},
+	})
+}
+
+func TestLifecycleHooks(t *testing.T) {
+	events := []string(nil)
+	lifecycle := func(name, text string) *Lifecycle {
+		return &Lifecycle{
+			OnMount: func(elem base.Element) {
+				events = append(events, "mount "+name+" "+elem.(base.Proper).Props().(*Label).Text)
+			},
+			OnUpdate: func(elem base.Element) {
+				events = append(events, "update "+name+" "+elem.(base.Proper).Props().(*Label).Text)
+			},
+			OnClose: func() {
+				events = append(events, "close "+name)
+			},
+			Child: &Label{Text: text},
+		}
+	}
+
+	init := func() error {
+		window, err := NewWindow(t.Name(), &VBox{Children: []base.Widget{
+			lifecycle("a", "A"),
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+
+		err = window.SetChild(&VBox{Children: []base.Widget{
+			lifecycle("a2", "AB"), lifecycle("b", "B"),
+		}})
+		if err != nil {
+			t.Errorf("Failed to set child, %s", err)
+		}
+		err = window.SetChild(&VBox{})
+		if err != nil {
+			t.Errorf("Failed to set child, %s", err)
+		}
+		window.Close()
+
+		// The callbacks from the most recent update are used when closing.
+		want := []string{
+			"mount a A",
+			"update a2 AB", "mount b B",
+			"close a2", "close b",
+		}
+		if !reflect.DeepEqual(events, want) {
+			t.Errorf("Incorrect callbacks, got %v, want %v", events, want)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
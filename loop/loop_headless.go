@@ -0,0 +1,56 @@
+//go:build headless
+// +build headless
+
+package loop
+
+import (
+	"bitbucket.org/rj/goey/internal/nopanic"
+)
+
+var (
+	actions = make(chan func())
+	quit    chan struct{}
+)
+
+func initRun() error {
+	// The channel is buffered so that a request to stop never blocks the
+	// GUI thread.
+	quit = make(chan struct{}, 1)
+	return nil
+}
+
+func terminateRun() {
+	// Do nothing
+}
+
+func run() {
+	// There is no platform event loop, so simply service actions scheduled
+	// using Do until asked to stop.
+	for {
+		select {
+		case action := <-actions:
+			action()
+		case <-quit:
+			return
+		}
+	}
+}
+
+func do(action func() error) error {
+	// Make channel for the return value of the action.
+	err := make(chan error, 1)
+
+	actions <- func() {
+		err <- nopanic.Wrap(action)
+	}
+
+	// Block on completion of action.
+	return nopanic.Unwrap(<-err)
+}
+
+func stop() {
+	select {
+	case quit <- struct{}{}:
+	default:
+	}
+}
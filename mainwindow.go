@@ -2,7 +2,6 @@ package goey
 
 import (
 	"errors"
-	"fmt"
 	"image"
 	"os"
 	"strconv"
@@ -24,6 +23,12 @@ var (
 // Window represents a top-level window that contain other widgets.
 type Window struct {
 	windowImpl
+
+	// Context inherited by all widgets in the window.  Each setting is held
+	// in a separate link of the chain, so that each can be updated.  The root
+	// of the chain holds the DPI, and is owned by the platform's windowImpl.
+	continueOnError *base.Context
+	direction       *base.Context
 }
 
 // NewWindow create a new top-level window for the application.
@@ -39,9 +44,14 @@ func NewWindow(title string, child base.Widget) (*Window, error) {
 	// be displayed (if necessary) with the relayout for the child.
 	w.horizontalScroll, w.verticalScroll = scrollDefaults()
 
+	// Create the root context.  The context is shared by all descendants, so
+	// changes to the window's settings are visible without remounting.
+	w.continueOnError = w.dpi.WithContinueOnError(false)
+	w.direction = w.continueOnError.WithDirection(base.LeftToRight)
+
 	// Mount the widget, and initialize its layout.
 	if child != nil {
-		newChild, err := child.Mount(w.control())
+		newChild, err := child.Mount(w.parent())
 		if err != nil {
 			w.Close()
 			return nil, err
@@ -66,23 +76,42 @@ func (w *Window) Close() {
 }
 
 // Child returns the mounted child for the window.  In general, this
-// method should not be used.
+// method should not be used, except by tools that need to inspect the mounted
+// elements.  Containers implement base.Parent, so base.Walk can be used to
+// traverse the tree.
 func (w *Window) Child() base.Element {
 	return w.child
 }
 
-// children assumes that the direct child of the window is a VBox, and then
-// returns the children of that element.  It is used for testing.
+// children returns the children of the window's child, if that element is
+// a container.  It is used for testing.
 func (w *Window) children() []base.Element {
 	if w.child == nil {
 		return nil
 	}
 
-	if vbox, ok := w.child.(*vboxElement); ok {
-		return vbox.children
-	}
+	return base.ChildrenOf(w.child)
+}
 
-	return nil
+// Direction returns the layout direction for the window's contents.
+func (w *Window) Direction() base.Direction {
+	return w.direction.Direction()
+}
+
+// DPI returns the DPI (dots per inch) of the monitor displaying the window.
+// The value is updated when the window is moved to a monitor with a different
+// scale factor.
+func (w *Window) DPI() image.Point {
+	return w.dpi.DPI()
+}
+
+// setDPI records a new DPI for the window, and then updates the layout.  Cached
+// layout results are discarded, since the sizes of native controls, once
+// converted to DIPs, may change with the scale.
+func (w *windowImpl) setDPI(dpi image.Point) {
+	w.dpi.Update(dpi)
+	base.InvalidateLayout()
+	w.setChildPost()
 }
 
 func (w *windowImpl) layoutChild(windowSize base.Size) base.Size {
@@ -98,10 +127,8 @@ func (w *windowImpl) layoutChild(windowSize base.Size) base.Size {
 	}
 
 	// Perform layout
-	size := w.child.Layout(constraints)
-	if !constraints.IsSatisfiedBy(size) {
-		fmt.Println("constraints not satisfied,", constraints, ",", size)
-	}
+	size := base.Layout(w.child, constraints)
+	w.debug.record(constraints, size)
 	return size
 }
 
@@ -149,6 +176,13 @@ func scrollDefaults() (horizontal, vertical bool) {
 	return (value & 2) == 2, (value & 1) == 1
 }
 
+// parent returns the control used as the parent when mounting the child.
+func (w *Window) parent() base.Control {
+	parent := w.control()
+	parent.Context = w.direction
+	return parent
+}
+
 // SetChild changes the child widget of the window.  As
 // necessary, GUI widgets will be created or destroyed so that the GUI widgets
 // match the widgets described by the parameter children.  The
@@ -171,7 +205,7 @@ func (w *Window) SetChild(child base.Widget) error {
 	}()
 
 	// Update the child element.
-	newChild, err := base.DiffChild(w.control(), w.child, child)
+	newChild, err := base.DiffChild(w.parent(), w.child, child)
 
 	// Whether or not there has been an error, we need to run platform-specific
 	// clean-up.  This is to recalculate min window size, update scrollbars, etc.
@@ -181,6 +215,29 @@ func (w *Window) SetChild(child base.Widget) error {
 	return err
 }
 
+// SetContinueOnError sets whether updates to the window's child should
+// continue after a widget fails to mount or update.  By default, updates stop
+// at the first error, which can leave the remainder of the GUI stale.  When
+// continuing, SetChild reconciles all of the widgets, and then returns a
+// base.MultiError that lists every child that failed.  The setting also applies
+// to updates from components.
+func (w *Window) SetContinueOnError(value bool) {
+	w.continueOnError.Update(value)
+}
+
+// SetDirection changes the layout direction for the window's contents.  For a
+// right-to-left layout, the children of an HBox are positioned starting from
+// the right, and horizontal alignment and insets are mirrored.  The setting
+// is inherited by all widgets in the window, and the layout is updated
+// immediately.
+//
+// The direction only affects the position of widgets.  It does not change
+// the direction of text within the platform's controls.
+func (w *Window) SetDirection(value base.Direction) {
+	w.direction.Update(value)
+	w.setChildPost()
+}
+
 // SetIcon changes the icon associated with the window.
 func (w *Window) SetIcon(img image.Image) error {
 	return w.setIcon(img)
@@ -0,0 +1,154 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"image"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/dialog"
+	"bitbucket.org/rj/goey/loop"
+)
+
+type windowImpl struct {
+	isClosed         bool
+	clientSize       base.Size
+	child            base.Element
+	horizontalScroll bool
+	verticalScroll   bool
+	onClosing        func() bool
+	windowTitle      string
+	dpi              *base.Context
+	debug            layoutDebug
+}
+
+func newWindow(title string, child base.Widget) (*Window, error) {
+	loop.AddLockCount(1)
+
+	// Update the global DPI
+	base.DPI.X, base.DPI.Y = 96, 96
+
+	width, height := sizeDefaults()
+	retval := &Window{windowImpl: windowImpl{
+		clientSize:  base.Size{base.FromPixelsX(int(width)), base.FromPixelsY(int(height))},
+		windowTitle: title,
+		dpi:         (*base.Context)(nil).WithDPI(base.DPI),
+		debug:       layoutDebugDefaults(),
+	}}
+	return retval, nil
+}
+
+func (w *windowImpl) onSize() {
+	if w.child == nil {
+		return
+	}
+
+	// Update the global DPI
+	base.DPI = w.dpi.DPI()
+
+	size := w.layoutChild(w.clientSize)
+	bounds := base.Rectangle{
+		base.Point{}, base.Point{size.Width, size.Height},
+	}
+	w.child.SetBounds(bounds)
+	w.debug.dump(w.child)
+}
+
+func (w *windowImpl) control() base.Control {
+	return base.Control{Handle: w}
+}
+
+func (w *windowImpl) close() {
+	if w.isClosed {
+		return
+	}
+
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.isClosed = true
+	// Release lock count on the GUI event loop.
+	loop.AddLockCount(-1)
+}
+
+func (w *windowImpl) message(m *dialog.Message) {
+	m.WithTitle(w.windowTitle)
+}
+
+func (w *windowImpl) openfiledialog(m *dialog.OpenFile) {
+	// Dialogs do not have a parent when headless.
+}
+
+func (w *windowImpl) savefiledialog(m *dialog.SaveFile) {
+	// Dialogs do not have a parent when headless.
+}
+
+// Resize changes the size of the client area of the window, and updates the
+// layout of the child.  This method is only available when building with the
+// tag headless, where it takes the place of the user resizing the window.
+func (w *windowImpl) Resize(size base.Size) {
+	w.clientSize = size
+	w.onSize()
+}
+
+// SetDPI changes the DPI of the window, and updates the layout of the child.
+// This method is only available when building with the tag headless, where it
+// takes the place of the user moving the window to a monitor with a different
+// scale factor.
+func (w *windowImpl) SetDPI(dpi image.Point) {
+	w.setDPI(dpi)
+}
+
+// RequestClose acts as if the user had clicked the close button on the window.
+// The window will be closed unless the callback set using SetOnClosing returns
+// true.  This method is only available when building with the tag headless.
+func (w *windowImpl) RequestClose() {
+	if w.onClosing != nil && w.onClosing() {
+		return
+	}
+	w.close()
+}
+
+// requestLayout finds the window that contains the control, and then redoes
+// the layout for that window.
+func requestLayout(parent base.Control) {
+	if w, ok := parent.Handle.(*windowImpl); ok && !w.isClosed {
+		w.setChildPost()
+	}
+}
+
+func (w *windowImpl) setChildPost() {
+	// Redo the layout so the children are placed.
+	w.onSize()
+}
+
+func (w *windowImpl) setScroll(horz, vert bool) {
+	w.horizontalScroll = horz
+	w.verticalScroll = vert
+	// Redo layout to account for new box constraints.
+	w.onSize()
+}
+
+func (w *windowImpl) show() {
+	// Nothing to show when headless.
+}
+
+func (w *windowImpl) setIcon(img image.Image) error {
+	// There is no title bar to display the icon when headless.
+	return nil
+}
+
+func (w *windowImpl) setOnClosing(callback func() bool) {
+	w.onClosing = callback
+}
+
+func (w *windowImpl) setTitle(value string) error {
+	w.windowTitle = value
+	return nil
+}
+
+func (w *windowImpl) title() (string, error) {
+	return w.windowTitle, nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -7,6 +10,7 @@ import (
 	"bitbucket.org/rj/goey/dialog"
 	"bitbucket.org/rj/goey/internal/syscall"
 	"bitbucket.org/rj/goey/loop"
+	"github.com/gotk3/gotk3/cairo"
 	"github.com/gotk3/gotk3/gdk"
 	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
@@ -14,6 +18,10 @@ import (
 
 var (
 	vscrollbarWidth base.Length
+
+	// Map from the native handle for top-level windows to the window.  This
+	// is used to find the window that contains a control.
+	windows = map[uintptr]*windowImpl{}
 )
 
 func init() {
@@ -39,6 +47,8 @@ type windowImpl struct {
 	verticalScrollVisible   bool
 	onClosing               func() bool
 	shClosing               glib.SignalHandle
+	dpi                     *base.Context
+	debug                   layoutDebug
 }
 
 func newWindow(title string, child base.Widget) (*Window, error) {
@@ -62,15 +72,22 @@ func newWindow(title string, child base.Widget) (*Window, error) {
 	}
 	scroll.Add(layout)
 
-	retval := &Window{windowImpl{
+	retval := &Window{windowImpl: windowImpl{
 		handle: app,
 		scroll: scroll,
 		layout: layout,
+		dpi:    (*base.Context)(nil).WithDPI(image.Point{96, 96}),
+		debug:  layoutDebugDefaults(),
 	}}
 	app.SetTitle(title)
 	app.SetBorderWidth(0)
 	app.Connect("destroy", mainwindowOnDestroy, retval)
 	app.Connect("size-allocate", mainwindowOnSizeAllocate, retval)
+	app.Connect("notify::scale-factor", mainwindowOnScaleFactor, retval)
+	if retval.debug.outline {
+		layout.ConnectAfter("draw", mainwindowOnDraw, retval)
+	}
+	windows[app.Native()] = &retval.windowImpl
 	app.SetDefaultSize(func() (int, int) {
 		w, h := sizeDefaults()
 		return int(w), int(h)
@@ -89,7 +106,7 @@ func (w *windowImpl) onSize() {
 	}
 
 	// Update the global DPI
-	base.DPI.X, base.DPI.Y = 96, 96
+	base.DPI = w.dpi.DPI()
 
 	width, height := w.handle.GetSize()
 	clientSize := base.Size{base.FromPixelsX(width), base.FromPixelsY(height)}
@@ -126,10 +143,14 @@ func (w *windowImpl) onSize() {
 		base.Point{}, base.Point{size.Width, size.Height},
 	}
 	w.child.SetBounds(bounds)
+	w.debug.dump(w.child)
+	if w.debug.outline {
+		w.layout.QueueDraw()
+	}
 }
 
 func (w *windowImpl) control() base.Control {
-	return base.Control{&w.layout.Container}
+	return base.Control{Handle: &w.layout.Container}
 }
 
 func (w *windowImpl) close() {
@@ -201,6 +222,22 @@ func get_vscrollbar_width(window *gtk.Window) (base.Length, error) {
 	return vscrollbarWidth, nil
 }
 
+// requestLayout finds the top-level window that contains the control, and
+// then redoes the layout for that window.
+func requestLayout(parent base.Control) {
+	if parent.Handle == nil {
+		return
+	}
+
+	toplevel, err := parent.Handle.GetToplevel()
+	if err != nil {
+		return
+	}
+	if w, ok := windows[toplevel.Native()]; ok {
+		w.setChildPost()
+	}
+}
+
 func (w *windowImpl) setChildPost() {
 	// Redo the layout so the children are placed.
 	if w.child != nil {
@@ -352,6 +389,7 @@ func (w *windowImpl) updateWindowMinSize() {
 }
 
 func mainwindowOnDestroy(widget *gtk.Window, mw *Window) {
+	delete(windows, widget.Native())
 	// Clear handle from the struct so that we dont' risk pointing to a
 	// non existent window.
 	mw.handle = nil
@@ -359,6 +397,38 @@ func mainwindowOnDestroy(widget *gtk.Window, mw *Window) {
 	loop.AddLockCount(-1)
 }
 
+func mainwindowOnDraw(widget *gtk.Layout, cr *cairo.Context, mw *Window) bool {
+	if mw.child == nil {
+		return false
+	}
+
+	// The bounds are relative to the layout, which may be scrolled.  Elements
+	// inside other native containers, such as tabs, are drawn relative to the
+	// window, and so will be misplaced.
+	dx := mw.scroll.GetHAdjustment().GetValue()
+	dy := mw.scroll.GetVAdjustment().GetValue()
+
+	cr.SetSourceRGBA(1, 0, 0, 0.75)
+	cr.SetLineWidth(1)
+	base.Walk(mw.child, func(elem base.Element) bool {
+		bounds := elem.Bounds()
+		cr.Rectangle(
+			float64(bounds.Min.X.PixelsX())-dx+0.5, float64(bounds.Min.Y.PixelsY())-dy+0.5,
+			float64(bounds.Dx().PixelsX())-1, float64(bounds.Dy().PixelsY())-1,
+		)
+		return true
+	})
+	cr.Stroke()
+	return false
+}
+
+func mainwindowOnScaleFactor(widget *gtk.Window, _ uintptr, mw *Window) {
+	// GTK measures in logical pixels, so the DPI used to convert lengths does
+	// not change.  However, the natural sizes of the native controls may
+	// change with the scale factor, so the layout needs to be redone.
+	mw.setDPI(image.Point{96, 96})
+}
+
 func mainwindowOnSizeAllocate(widget *gtk.Window, rect uintptr, mw *Window) {
 	mw.onSize()
 }
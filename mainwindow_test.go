@@ -1,16 +1,19 @@
 package goey
 
 import (
+	"errors"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
+	"reflect"
 	"strconv"
 	"testing"
 	"time"
 
 	"bitbucket.org/rj/goey/base"
 	"bitbucket.org/rj/goey/loop"
+	"bitbucket.org/rj/goey/mock"
 )
 
 func ExampleNewWindow() {
@@ -165,6 +168,50 @@ func TestWindow_SetChild(t *testing.T) {
 	})
 }
 
+func TestWindow_SetContinueOnError(t *testing.T) {
+	testingWindow(t, func(t *testing.T, mw *Window) {
+		err1 := errors.New("fake error 1 for mounting widget")
+		err2 := errors.New("fake error 2 for mounting widget")
+
+		err := loop.Do(func() error {
+			mw.SetContinueOnError(true)
+			err := mw.SetChild(&VBox{Children: []base.Widget{
+				&mock.Widget{Err: err1},
+				&Label{Text: "A"},
+				&mock.Widget{Err: err2},
+				&Label{Text: "B"},
+			}})
+
+			errs, ok := err.(base.MultiError)
+			if !ok {
+				t.Errorf("Returned error is not a MultiError, got %v", err)
+				return nil
+			}
+			want := base.MultiError{{Index: 0, Err: err1}, {Index: 2, Err: err2}}
+			if !reflect.DeepEqual(errs, want) {
+				t.Errorf("Returned error does not match, got %v, want %v", errs, want)
+			}
+
+			// All of the widgets that could be mounted should be present.
+			vbox, ok := mw.Child().(*vboxElement)
+			if !ok {
+				t.Errorf("Child was not mounted, got %v", mw.Child())
+				return nil
+			}
+			if got := len(vbox.children); got != 4 {
+				t.Errorf("Incorrect number of children, got %d, want %d", got, 4)
+			}
+			if _, ok := vbox.children[3].(*labelElement); !ok {
+				t.Errorf("Failed to mount child after error, got %v", vbox.children[3])
+			}
+			return nil
+		})
+		if err != nil {
+			t.Errorf("Error in Do, %s", err)
+		}
+	})
+}
+
 func makeImage(t *testing.T, index int) image.Image {
 	colors := [3]color.RGBA{
 		{255, 0, 0, 255},
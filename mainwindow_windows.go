@@ -1,8 +1,12 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
 	"fmt"
 	"image"
+	"os"
 	"syscall"
 	"unsafe"
 
@@ -46,7 +50,7 @@ func init() {
 
 type windowImpl struct {
 	hWnd                    win.HWND
-	dpi                     image.Point
+	dpi                     *base.Context
 	windowRectDelta         image.Point
 	windowMinSize           image.Point
 	child                   base.Element
@@ -58,6 +62,7 @@ type windowImpl struct {
 	verticalScroll          bool
 	verticalScrollVisible   bool
 	verticalScrollPos       base.Length
+	debug                   layoutDebug
 }
 
 func registerMainWindowClass(hInst win.HINSTANCE, wndproc uintptr) (win.ATOM, error) {
@@ -136,6 +141,7 @@ func (w *windowImpl) onSize(hwnd win.HWND) {
 		base.Point{-w.horizontalScrollPos, -w.verticalScrollPos},
 		base.Point{size.Width - w.horizontalScrollPos, size.Height - w.verticalScrollPos},
 	})
+	w.debug.dump(w.child)
 
 	// Update the position of all of the children
 	win.InvalidateRect(hwnd, &rect, true)
@@ -202,13 +208,21 @@ func newWindow(title string, child base.Widget) (*Window, error) {
 		win.SendMessage(hwnd, win.WM_SETFONT, 0, 0)
 	}
 
-	retval := &Window{windowImpl{hWnd: hwnd}}
+	retval := &Window{windowImpl: windowImpl{
+		hWnd:  hwnd,
+		debug: layoutDebugDefaults(),
+	}}
+	if retval.debug.outline {
+		fmt.Fprintln(os.Stderr, "error: GOEY_LAYOUT_DEBUG: Outlines not supported on this platform.")
+	}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(&retval.windowImpl)))
 
 	// Determine the DPI for this window
 	hdc := win.GetDC(hwnd)
-	retval.dpi.X = int(win.GetDeviceCaps(hdc, win.LOGPIXELSX))
-	retval.dpi.Y = int(win.GetDeviceCaps(hdc, win.LOGPIXELSY))
+	retval.dpi = (*base.Context)(nil).WithDPI(scaleDPI(
+		int(win.GetDeviceCaps(hdc, win.LOGPIXELSX)),
+		int(win.GetDeviceCaps(hdc, win.LOGPIXELSY)),
+	))
 	win.ReleaseDC(hwnd, hdc)
 
 	// Calculate the extra width and height required for the borders
@@ -222,7 +236,7 @@ func newWindow(title string, child base.Widget) (*Window, error) {
 }
 
 func (w *windowImpl) control() base.Control {
-	return base.Control{w.hWnd}
+	return base.Control{HWnd: w.hWnd}
 }
 
 func (w *windowImpl) close() {
@@ -306,6 +320,18 @@ func (w *windowImpl) Screenshot() (image.Image, error) {
 	return img, nil
 }
 
+// requestLayout finds the top-level window that contains the control, and
+// then redoes the layout for that window.
+func requestLayout(parent base.Control) {
+	hwnd := win.GetAncestor(parent.HWnd, win.GA_ROOT)
+	if hwnd == 0 {
+		return
+	}
+	if w := windowGetPtr(hwnd); w != nil {
+		w.setChildPost()
+	}
+}
+
 // setChild updates the child element of the window.  It also updates any
 // cached data linked to the child element, in particular the window's
 // minimum size.  This function will also perform layout on the child.
@@ -353,10 +379,33 @@ func (w *windowImpl) setScroll(hscroll, vscroll bool) {
 }
 
 func (w *windowImpl) setScrollPos(direction int32, wParam uintptr) {
+	// If the position has changed, scroll window and update it.
+	if pos, ok := updateScrollPos(w.hWnd, direction, wParam); ok {
+		if direction == win.SB_HORZ {
+			w.horizontalScrollPos = base.FromPixelsX(int(pos))
+		} else {
+			w.verticalScrollPos = base.FromPixelsY(int(pos))
+		}
+		w.child.SetBounds(base.Rectangle{
+			base.Point{-w.horizontalScrollPos, -w.verticalScrollPos},
+			base.Point{w.childSize.Width - w.horizontalScrollPos, w.childSize.Height - w.verticalScrollPos},
+		})
+
+		// TODO:  Use ScrollWindow function to reduce flicker during scrolling
+		rect := win.RECT{}
+		win.GetClientRect(w.hWnd, &rect)
+		win.InvalidateRect(w.hWnd, &rect, true)
+	}
+}
+
+// updateScrollPos handles a WM_HSCROLL or WM_VSCROLL message for a standard
+// scroll bar of the window.  The function returns the new position, and
+// whether that position differs from the previous position.
+func updateScrollPos(hwnd win.HWND, direction int32, wParam uintptr) (int32, bool) {
 	// Get all of the scroll bar information.
 	si := win.SCROLLINFO{FMask: win.SIF_ALL}
 	si.CbSize = uint32(unsafe.Sizeof(si))
-	win.GetScrollInfo(w.hWnd, direction, &si)
+	win.GetScrollInfo(hwnd, direction, &si)
 
 	// Save the position for comparison later on.
 	currentPos := si.NPos
@@ -402,26 +451,10 @@ func (w *windowImpl) setScrollPos(direction int32, wParam uintptr) {
 	// Set the position and then retrieve it.  Due to adjustments
 	// by Windows it may not be the same as the value set.
 	si.FMask = win.SIF_POS
-	win.SetScrollInfo(w.hWnd, direction, &si, true)
-	win.GetScrollInfo(w.hWnd, direction, &si)
+	win.SetScrollInfo(hwnd, direction, &si, true)
+	win.GetScrollInfo(hwnd, direction, &si)
 
-	// If the position has changed, scroll window and update it.
-	if si.NPos != currentPos {
-		if direction == win.SB_HORZ {
-			w.horizontalScrollPos = base.FromPixelsX(int(si.NPos))
-		} else {
-			w.verticalScrollPos = base.FromPixelsY(int(si.NPos))
-		}
-		w.child.SetBounds(base.Rectangle{
-			base.Point{-w.horizontalScrollPos, -w.verticalScrollPos},
-			base.Point{w.childSize.Width - w.horizontalScrollPos, w.childSize.Height - w.verticalScrollPos},
-		})
-
-		// TODO:  Use ScrollWindow function to reduce flicker during scrolling
-		rect := win.RECT{}
-		win.GetClientRect(w.hWnd, &rect)
-		win.InvalidateRect(w.hWnd, &rect, true)
-	}
+	return si.NPos, si.NPos != currentPos
 }
 
 func (w *windowImpl) show() {
@@ -508,7 +541,7 @@ func (w *windowImpl) setOnClosing(callback func() bool) {
 }
 
 func (w *windowImpl) setTitle(value string) error {
-	return Control{w.hWnd}.SetText(value)
+	return Control{hWnd: w.hWnd}.SetText(value)
 }
 
 func (w *windowImpl) title() (string, error) {
@@ -516,7 +549,12 @@ func (w *windowImpl) title() (string, error) {
 }
 
 func (w *windowImpl) updateGlobalDPI() {
-	base.DPI = image.Point{int(float32(w.dpi.X) * Scale), int(float32(w.dpi.Y) * Scale)}
+	base.DPI = w.dpi.DPI()
+}
+
+// scaleDPI adjusts the DPI reported by the system for the package's scale.
+func scaleDPI(x, y int) image.Point {
+	return image.Point{int(float32(x) * Scale), int(float32(y) * Scale)}
 }
 
 func (w *windowImpl) updateWindowMinSize() {
@@ -640,6 +678,22 @@ func windowWindowProc(hwnd win.HWND, msg uint32, wParam uintptr, lParam uintptr)
 		windowGetPtr(hwnd).onSize(hwnd)
 		// Defer to the default window proc
 
+	case win.WM_DPICHANGED:
+		if w := windowGetPtr(hwnd); w != nil {
+			// The new DPI is packed into wParam, and lParam points to the
+			// window rectangle suggested for the new scale.
+			w.setDPI(scaleDPI(
+				int(win.LOWORD(uint32(wParam))),
+				int(win.HIWORD(uint32(wParam))),
+			))
+			rect := (*win.RECT)(unsafe.Pointer(lParam))
+			win.SetWindowPos(hwnd, 0, rect.Left, rect.Top,
+				rect.Right-rect.Left, rect.Bottom-rect.Top,
+				win.SWP_NOZORDER|win.SWP_NOACTIVATE)
+			return 0
+		}
+		// Defer to the default window proc
+
 	case win.WM_GETMINMAXINFO:
 		if w := windowGetPtr(hwnd); w != nil {
 			if w.windowMinSize.X == 0 {
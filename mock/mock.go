@@ -42,6 +42,7 @@ func NewList(sizes ...base.Size) []base.Element {
 type Widget struct {
 	Size base.Size
 	Err  error
+	Key  string
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -50,6 +51,12 @@ func (*Widget) Kind() *base.Kind {
 	return &mockKind
 }
 
+// GetKey returns the key for use in the Keyed interface.
+// Users should not need to use this method directly.
+func (w *Widget) GetKey() string {
+	return w.Key
+}
+
 // Mount creates an mock control.
 func (w *Widget) Mount(parent base.Control) (base.Element, error) {
 	// Check if the widget is supposed to fail when mounted.
@@ -60,6 +67,7 @@ func (w *Widget) Mount(parent base.Control) (base.Element, error) {
 	// Create a mock element.
 	return &Element{
 		Size: w.Size,
+		Key:  w.Key,
 	}, nil
 }
 
@@ -68,6 +76,7 @@ func (w *Widget) Mount(parent base.Control) (base.Element, error) {
 // there is no control associated with this element.
 type Element struct {
 	Size base.Size
+	Key  string
 
 	bounds base.Rectangle
 	closed bool
@@ -82,6 +91,11 @@ func (w *Element) Close() {
 	w.closed = true
 }
 
+// GetKey returns the key for use in the Keyed interface.
+func (w *Element) GetKey() string {
+	return w.Key
+}
+
 // Kind returns the concrete type for the Element.
 func (*Element) Kind() *base.Kind {
 	return &mockKind
@@ -110,6 +124,7 @@ func (w *Element) MinIntrinsicWidth(base.Length) base.Length {
 func (w *Element) Props() base.Widget {
 	return &Widget{
 		Size: w.Size,
+		Key:  w.Key,
 	}
 }
 
@@ -124,6 +139,11 @@ func (w *Element) SetBounds(bounds base.Rectangle) {
 }
 
 func (w *Element) updateProps(data *Widget) error {
+	// Check if the widget is supposed to fail when updated.
+	if data.Err != nil {
+		return data.Err
+	}
+
 	w.Size = data.Size
 	return nil
 }
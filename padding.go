@@ -8,7 +8,8 @@ var (
 	paddingKind = base.NewKind("bitbucket.org/rj/goey.Padding")
 )
 
-// Insets describe padding that should ba added around a widget.
+// Insets describe padding that should ba added around a widget.  For a
+// right-to-left layout, the insets for the left and right are swapped.
 type Insets struct {
 	Top    base.Length
 	Right  base.Length
@@ -23,6 +24,15 @@ func DefaultInsets() Insets {
 	return Insets{padding, padding, padding, padding}
 }
 
+// forDirection returns the insets to use for the layout direction.  For a
+// right-to-left layout, the insets for the left and right are swapped.
+func (i Insets) forDirection(context *base.Context) Insets {
+	if context.Direction() == base.RightToLeft {
+		i.Left, i.Right = i.Right, i.Left
+	}
+	return i
+}
+
 // UniformInsets returns a padding description where the padding is equal on
 // all four sides.
 func UniformInsets(l base.Length) Insets {
@@ -49,7 +59,7 @@ func (*Padding) Kind() *base.Kind {
 // will be a child of the widget specified by parent.
 func (w *Padding) Mount(parent base.Control) (base.Element, error) {
 	child, err := base.Mount(parent, w.Child)
-	if err != nil {
+	if child == nil {
 		return nil, err
 	}
 
@@ -57,7 +67,7 @@ func (w *Padding) Mount(parent base.Control) (base.Element, error) {
 		parent: parent,
 		child:  child,
 		insets: w.Insets,
-	}, nil
+	}, err
 }
 
 type paddingElement struct {
@@ -65,6 +75,18 @@ type paddingElement struct {
 	child     base.Element
 	childSize base.Size
 	insets    Insets
+	bounds    base.Rectangle
+}
+
+func (w *paddingElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *paddingElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
 }
 
 func (w *paddingElement) Close() {
@@ -83,7 +105,7 @@ func (w *paddingElement) Layout(bc base.Constraints) base.Size {
 	vinset := w.insets.Top + w.insets.Bottom
 
 	innerConstraints := bc.Inset(hinset, vinset)
-	w.childSize = w.child.Layout(innerConstraints)
+	w.childSize = base.Layout(w.child, innerConstraints)
 	return base.Size{
 		w.childSize.Width + hinset,
 		w.childSize.Height + vinset,
@@ -100,11 +122,20 @@ func (w *paddingElement) MinIntrinsicWidth(height base.Length) base.Length {
 	return w.child.MinIntrinsicWidth(height) + hinset
 }
 
+func (w *paddingElement) Props() base.Widget {
+	return &Padding{
+		Insets: w.insets,
+		Child:  base.PropsOf(w.child),
+	}
+}
+
 func (w *paddingElement) SetBounds(bounds base.Rectangle) {
-	bounds.Min.X += w.insets.Left
-	bounds.Min.Y += w.insets.Top
-	bounds.Max.X -= w.insets.Right
-	bounds.Max.Y -= w.insets.Bottom
+	w.bounds = bounds
+	insets := w.insets.forDirection(w.parent.Context)
+	bounds.Min.X += insets.Left
+	bounds.Min.Y += insets.Top
+	bounds.Max.X -= insets.Right
+	bounds.Max.Y -= insets.Bottom
 	w.child.SetBounds(bounds)
 }
 
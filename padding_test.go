@@ -8,18 +8,6 @@ import (
 	"bitbucket.org/rj/goey/mock"
 )
 
-func (w *paddingElement) Props() base.Widget {
-	child := base.Widget(nil)
-	if w.child != nil {
-		child = w.child.(Proper).Props()
-	}
-
-	return &Padding{
-		Insets: w.insets,
-		Child:  child,
-	}
-}
-
 func TestPaddingMount(t *testing.T) {
 	// These should all be able to mount without error.
 	testingMountWidgets(t,
@@ -95,3 +83,18 @@ func TestPaddingMinIntrinsicSize(t *testing.T) {
 		}
 	}
 }
+
+func TestPaddingRightToLeft(t *testing.T) {
+	child := mock.New(base.Size{10 * DIP, 10 * DIP})
+	elem := paddingElement{
+		parent: base.Control{Context: (*base.Context)(nil).WithDirection(base.RightToLeft)},
+		child:  child,
+		insets: Insets{Top: 1 * DIP, Right: 2 * DIP, Bottom: 3 * DIP, Left: 4 * DIP},
+	}
+
+	// The insets for the left and right should be swapped.
+	elem.SetBounds(base.Rect(0, 0, 100*DIP, 100*DIP))
+	if got, want := child.Bounds(), base.Rect(2*DIP, 1*DIP, 96*DIP, 97*DIP); got != want {
+		t.Errorf("Incorrect bounds for child, got %s, want %s", got, want)
+	}
+}
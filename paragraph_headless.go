@@ -0,0 +1,72 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"bitbucket.org/rj/goey/base"
+)
+
+type paragraphElement struct {
+	Control
+
+	text  string
+	align TextAlignment
+}
+
+func (w *P) mount(parent base.Control) (base.Element, error) {
+	retval := &paragraphElement{
+		text:  w.Text,
+		align: w.Align,
+	}
+	return retval, nil
+}
+
+func (w *paragraphElement) Props() base.Widget {
+	return &P{
+		Text:  w.text,
+		Align: w.align,
+	}
+}
+
+func (w *paragraphElement) measureReflowLimits() {
+	paragraphMaxWidth = textCharWidth.Scale(80, 1)
+}
+
+func (w *paragraphElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if width == base.Inf {
+		width = w.maxReflowWidth()
+	}
+
+	// Count the number of lines required, breaking lines at the character
+	// that would overflow the width.
+	perLine := int(width / textCharWidth)
+	if perLine < 1 {
+		perLine = 1
+	}
+	lines := 0
+	for _, v := range strings.Split(w.text, "\n") {
+		lines += (utf8.RuneCountInString(v) + perLine - 1) / perLine
+		if v == "" {
+			lines++
+		}
+	}
+	return textLineHeight.Scale(lines, 1)
+}
+
+func (w *paragraphElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if height != base.Inf {
+		panic("not implemented")
+	}
+
+	return min(measureText(w.text).Width, w.minReflowWidth())
+}
+
+func (w *paragraphElement) updateProps(data *P) error {
+	w.text = data.Text
+	w.align = data.Align
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -53,7 +56,7 @@ func (w *P) mount(parent base.Control) (base.Element, error) {
 	handle.SetHAlign(w.Align.halign())
 	handle.SetLineWrap(true)
 
-	retval := &paragraphElement{Control{&handle.Widget}}
+	retval := &paragraphElement{Control{handle: &handle.Widget}}
 	handle.Connect("destroy", paragraphOnDestroy, retval)
 	handle.Show()
 
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -28,7 +31,7 @@ func (w *P) mount(parent base.Control) (base.Element, error) {
 		return nil, err
 	}
 
-	retval := &paragraphElement{Control: Control{hwnd}, text: text}
+	retval := &paragraphElement{Control: Control{hWnd: hwnd}, text: text}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
 
 	return retval, nil
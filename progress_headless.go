@@ -0,0 +1,56 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type progressElement struct {
+	Control
+
+	value    int
+	min, max int
+}
+
+func (w *Progress) mount(parent base.Control) (base.Element, error) {
+	retval := &progressElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *progressElement) Layout(bc base.Constraints) base.Size {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	width := w.MinIntrinsicWidth(0)
+	if bc.Max.Width > 355*DIP {
+		width = 355 * DIP
+	}
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *progressElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 15 * DIP
+}
+
+func (w *progressElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 160 * DIP
+}
+
+func (w *progressElement) Props() base.Widget {
+	return &Progress{
+		Value: w.value,
+		Min:   w.min,
+		Max:   w.max,
+	}
+}
+
+func (w *progressElement) updateProps(data *Progress) error {
+	w.value = data.Value
+	w.min = data.Min
+	w.max = data.Max
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -22,7 +25,7 @@ func (w *Progress) mount(parent base.Control) (base.Element, error) {
 	control.SetFraction(float64(w.Value-w.Min) / float64(w.Max-w.Min))
 
 	retval := &progressElement{
-		Control: Control{&control.Widget},
+		Control: Control{handle: &control.Widget},
 		min:     w.Min,
 		max:     w.Max,
 	}
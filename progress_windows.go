@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -29,7 +32,7 @@ func (w *Progress) mount(parent base.Control) (base.Element, error) {
 	win.SendMessage(hwnd, win.PBM_SETPOS, uintptr(w.Value), 0)
 
 	retval := &progressElement{
-		Control: Control{hwnd},
+		Control: Control{hWnd: hwnd},
 	}
 	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
 
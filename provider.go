@@ -0,0 +1,132 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	providerKind = base.NewKind("bitbucket.org/rj/goey.Provider")
+)
+
+// Provider describes a widget that makes a value available to all of its
+// descendants, such as a theme, a locale, or a handle to application services.
+// Descendants can look up the value using the Context in the parent Control
+// passed to Mount, or, for components, using ComponentState.Lookup.
+//
+// When the value is changed, the provider updates its child, so that any
+// descendants that consumed the value will see the new value.
+//
+// The key must be comparable, and should be of an unexported type to avoid
+// collisions between packages.  If the key changes, the child will be
+// mounted again.
+type Provider struct {
+	Key   interface{} // Key used by descendants to look up the value.
+	Value interface{} // Value provided to descendants.
+	Child base.Widget // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Provider) Kind() *base.Kind {
+	return &providerKind
+}
+
+// Mount creates the child widget in the GUI, with a context that provides the
+// value.  The newly created widget will be a child of the widget specified by
+// parent.
+func (w *Provider) Mount(parent base.Control) (base.Element, error) {
+	parent = parent.WithValue(w.Key, w.Value)
+
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	return &providerElement{
+		parent: parent,
+		child:  child,
+		key:    w.Key,
+	}, err
+}
+
+type providerElement struct {
+	parent base.Control // Parent for the child, which includes our context.
+	child  base.Element
+	key    interface{}
+	bounds base.Rectangle
+}
+
+func (w *providerElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *providerElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *providerElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*providerElement) Kind() *base.Kind {
+	return &providerKind
+}
+
+func (w *providerElement) Layout(bc base.Constraints) base.Size {
+	return base.Layout(w.child, bc)
+}
+
+func (w *providerElement) MinIntrinsicHeight(width base.Length) base.Length {
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *providerElement) MinIntrinsicWidth(height base.Length) base.Length {
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *providerElement) Props() base.Widget {
+	return &Provider{
+		Key:   w.key,
+		Value: w.parent.Value(w.key),
+		Child: base.PropsOf(w.child),
+	}
+}
+
+func (w *providerElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	w.child.SetBounds(bounds)
+}
+
+func (w *providerElement) updateProps(data *Provider) (err error) {
+	if data.Key != w.key {
+		// Descendants keep a reference to the context, so a new context
+		// requires that the child be mounted again.  The current child is
+		// kept if the new child fails to mount.
+		parent := w.parent
+		parent.Context = parent.Context.Parent()
+		parent = parent.WithValue(data.Key, data.Value)
+		child, err := base.Mount(parent, data.Child)
+		if err != nil && (child == nil || !parent.Context.ContinueOnError()) {
+			return err
+		}
+		w.child.Close()
+		w.child = child
+		w.parent = parent
+		w.key = data.Key
+		return err
+	}
+
+	w.parent.Context.Update(data.Value)
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	return err
+}
+
+func (w *providerElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Provider))
+}
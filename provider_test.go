@@ -0,0 +1,123 @@
+package goey
+
+import (
+	"errors"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+	"bitbucket.org/rj/goey/mock"
+)
+
+type testingProviderKey int
+
+func TestProviderMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Provider{Key: testingProviderKey(0), Value: "A", Child: &Button{Text: "A"}},
+		&Provider{Key: testingProviderKey(1), Value: 1, Child: &Label{Text: "B"}},
+		&Provider{Key: testingProviderKey(0)},
+	)
+}
+
+func TestProviderClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Provider{Key: testingProviderKey(0), Value: "A", Child: &Button{Text: "A"}},
+		&Provider{Key: testingProviderKey(0)},
+	)
+}
+
+func TestProviderUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Provider{Key: testingProviderKey(0), Value: "A", Child: &Button{Text: "A"}},
+		&Provider{Key: testingProviderKey(1), Value: 1, Child: &Label{Text: "B"}},
+	}, []base.Widget{
+		&Provider{Key: testingProviderKey(0), Value: "B", Child: &Button{Text: "B"}},
+		&Provider{Key: testingProviderKey(2), Value: 2, Child: &Label{Text: "C"}},
+	})
+}
+
+func TestProviderUpdatePropsError(t *testing.T) {
+	err := errors.New("Mock error 1")
+	size := base.Size{20 * DIP, 10 * DIP}
+
+	elem, mountErr := (&Provider{Key: testingProviderKey(0), Value: "A", Child: &mock.Widget{Size: size}}).Mount(base.Control{})
+	if mountErr != nil {
+		t.Fatalf("Failed to mount, %s", mountErr)
+	}
+	defer elem.Close()
+
+	// If the new child fails to mount, the current child and key are kept.
+	if got := elem.UpdateProps(&Provider{Key: testingProviderKey(1), Value: "B", Child: &mock.Widget{Err: err}}); got != err {
+		t.Errorf("Incorrect error on update, got %v, want %v", got, err)
+	}
+	props := elem.(base.Proper).Props().(*Provider)
+	if props.Key != testingProviderKey(0) || props.Value != "A" {
+		t.Errorf("Incorrect props after failed update, got %v=%v", props.Key, props.Value)
+	}
+	if got := elem.Layout(base.Loose(base.Size{100 * DIP, 100 * DIP})); got != size {
+		t.Errorf("Incorrect size after failed update, got %s, want %s", got, size)
+	}
+	elem.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+}
+
+func TestProviderLookup(t *testing.T) {
+	// The label shows the value provided by the nearest ancestor.
+	consumer := func(key testingProviderKey) *Component {
+		return &Component{
+			Render: func(state *ComponentState) base.Widget {
+				text, _ := state.Lookup(key).(string)
+				return &Label{Text: text}
+			},
+		}
+	}
+	render := func(outer, inner string) base.Widget {
+		return &Provider{Key: testingProviderKey(0), Value: outer, Child: &VBox{Children: []base.Widget{
+			consumer(0),
+			consumer(1),
+			&Provider{Key: testingProviderKey(0), Value: inner, Child: consumer(0)},
+		}}}
+	}
+	labelText := func(window *Window, path ...int) string {
+		elem := window.Child()
+		for _, v := range path {
+			elem = base.ChildrenOf(elem)[v]
+		}
+		return elem.(base.Proper).Props().(*Label).Text
+	}
+
+	init := func() error {
+		window, err := NewWindow(t.Name(), render("A", "B"))
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		check := func(outer, inner string) {
+			if got := labelText(window, 0, 0, 0); got != outer {
+				t.Errorf("Incorrect text for consumer, got %s, want %s", got, outer)
+			}
+			if got := labelText(window, 0, 1, 0); got != "" {
+				t.Errorf("Incorrect text for consumer of missing key, got %s, want empty", got)
+			}
+			if got := labelText(window, 0, 2, 0, 0); got != inner {
+				t.Errorf("Incorrect text for nested consumer, got %s, want %s", got, inner)
+			}
+		}
+		check("A", "B")
+
+		// Changing the provided values should update the consumers.
+		err = window.SetChild(render("C", "D"))
+		if err != nil {
+			t.Errorf("Failed to set child, %s", err)
+			return nil
+		}
+		check("C", "D")
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
@@ -0,0 +1,146 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	scrollKind = base.NewKind("bitbucket.org/rj/goey.Scroll")
+)
+
+// Scroll describes a widget that shows scrollbars, so that its child widget
+// can be larger than the space available.  Along each axis where scrolling is
+// enabled, the child is laid out without any limit on its size.  Unlike the
+// scrolling provided by Window.SetScroll, a scroll region can be placed
+// anywhere in the layout, for example in one half of a split layout.
+//
+// Along each axis where scrolling is enabled, the scroll region can shrink to
+// any size.  Place the widget inside an Expand, or another widget that
+// provides bounded constraints, so that it has space to fill.
+//
+// The scroll position is the offset of the visible area within the child.
+// The position is only applied when it differs from the position given when
+// the widget was last mounted or updated, so that an update does not undo any
+// scrolling by the user.  OnScroll is called whenever the user changes the
+// scroll position.
+type Scroll struct {
+	Horizontal bool        // If true, the child can be scrolled horizontally.
+	Vertical   bool        // If true, the child can be scrolled vertically.
+	Position   base.Point  // Offset of the visible area within the child.
+	Child      base.Widget // Child widget.
+
+	OnScroll func(base.Point) // OnScroll will be called whenever the user changes the scroll position.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Scroll) Kind() *base.Kind {
+	return &scrollKind
+}
+
+// Mount creates a scrolling region in the GUI.  The newly created widget
+// will be a child of the widget specified by parent.
+func (w *Scroll) Mount(parent base.Control) (base.Element, error) {
+	// Forward to the platform-dependant code
+	return w.mount(parent)
+}
+
+func (w *scrollElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (*scrollElement) Kind() *base.Kind {
+	return &scrollKind
+}
+
+func (w *scrollElement) Layout(bc base.Constraints) base.Size {
+	// Space is reserved for any scrollbars, and then the child is given
+	// unbounded constraints along the axes that scroll.
+	bars := w.scrollbars()
+	cbc := bc.Inset(bars.Width, bars.Height)
+	if w.horizontal {
+		cbc.Min.Width, cbc.Max.Width = 0, base.Inf
+	}
+	if w.vertical {
+		cbc.Min.Height, cbc.Max.Height = 0, base.Inf
+	}
+	size := base.Layout(w.child, cbc)
+	viewport := bc.Constrain(base.Size{size.Width + bars.Width, size.Height + bars.Height})
+
+	// If the child is smaller than the visible area, it is stretched to fill
+	// that area.
+	if inner := (base.Size{viewport.Width - bars.Width, viewport.Height - bars.Height}); (w.horizontal && size.Width < inner.Width) || (w.vertical && size.Height < inner.Height) {
+		if w.horizontal {
+			cbc.Min.Width = max(0, inner.Width)
+		}
+		if w.vertical {
+			cbc.Min.Height = max(0, inner.Height)
+		}
+		size = base.Layout(w.child, cbc)
+	}
+
+	w.childSize = size
+	return viewport
+}
+
+func (w *scrollElement) MinIntrinsicHeight(width base.Length) base.Length {
+	bars := w.scrollbars()
+	if w.vertical {
+		return bars.Height
+	}
+	if w.horizontal {
+		return w.child.MinIntrinsicHeight(base.Inf) + bars.Height
+	}
+	return w.child.MinIntrinsicHeight(guardInf(width, max(0, width-bars.Width))) + bars.Height
+}
+
+func (w *scrollElement) MinIntrinsicWidth(height base.Length) base.Length {
+	bars := w.scrollbars()
+	if w.horizontal {
+		return bars.Width
+	}
+	if w.vertical {
+		return w.child.MinIntrinsicWidth(base.Inf) + bars.Width
+	}
+	return w.child.MinIntrinsicWidth(guardInf(height, max(0, height-bars.Height))) + bars.Width
+}
+
+// maxPosition returns the largest scroll position, for a visible area of the
+// specified size.
+func (w *scrollElement) maxPosition(viewport base.Size) base.Point {
+	return base.Point{
+		max(0, w.childSize.Width-viewport.Width),
+		max(0, w.childSize.Height-viewport.Height),
+	}
+}
+
+// clampPosition limits the scroll position to the range allowed for the child,
+// and for the axes that scroll.
+func (w *scrollElement) clampPosition(pos base.Point, viewport base.Size) base.Point {
+	limit := w.maxPosition(viewport)
+	if !w.horizontal {
+		limit.X = 0
+	}
+	if !w.vertical {
+		limit.Y = 0
+	}
+	return base.Point{pos.X.Clamp(0, limit.X), pos.Y.Clamp(0, limit.Y)}
+}
+
+func (w *scrollElement) Props() base.Widget {
+	return &Scroll{
+		Horizontal: w.horizontal,
+		Vertical:   w.vertical,
+		Position:   w.position,
+		Child:      base.PropsOf(w.child),
+		OnScroll:   w.onScroll,
+	}
+}
+
+func (w *scrollElement) UpdateProps(data base.Widget) error {
+	// Forward to the platform-dependant code
+	return w.updateProps(data.(*Scroll))
+}
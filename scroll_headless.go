@@ -0,0 +1,96 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type scrollElement struct {
+	Control
+	parent     base.Control
+	child      base.Element
+	childSize  base.Size
+	horizontal bool
+	vertical   bool
+	position   base.Point
+	requested  base.Point
+	onScroll   func(base.Point)
+}
+
+func (w *Scroll) mount(parent base.Control) (base.Element, error) {
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	retval := &scrollElement{
+		parent:     parent,
+		child:      child,
+		horizontal: w.Horizontal,
+		vertical:   w.Vertical,
+		position:   w.Position,
+		requested:  w.Position,
+		onScroll:   w.OnScroll,
+	}
+	return retval, err
+}
+
+func (w *scrollElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.Control.Close()
+}
+
+func (w *scrollElement) scrollbars() base.Size {
+	// Scrollbars are assumed to overlay the contents when headless.
+	return base.Size{}
+}
+
+// ScrollTo changes the scroll position, as if the user had moved the
+// scrollbars.
+func (w *scrollElement) ScrollTo(pos base.Point) {
+	bounds := w.Control.Bounds()
+	pos = w.clampPosition(pos, base.Size{bounds.Dx(), bounds.Dy()})
+	if pos == w.position {
+		return
+	}
+
+	w.position = pos
+	w.setChildBounds(bounds)
+	if w.onScroll != nil {
+		w.onScroll(pos)
+	}
+}
+
+func (w *scrollElement) SetBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+	w.position = w.clampPosition(w.position, base.Size{bounds.Dx(), bounds.Dy()})
+	w.setChildBounds(bounds)
+}
+
+func (w *scrollElement) setChildBounds(bounds base.Rectangle) {
+	origin := bounds.Min.Sub(w.position)
+	w.child.SetBounds(base.Rectangle{
+		origin,
+		origin.Add(base.Point{w.childSize.Width, w.childSize.Height}),
+	})
+}
+
+func (w *scrollElement) updateProps(data *Scroll) error {
+	w.horizontal = data.Horizontal
+	w.vertical = data.Vertical
+	w.onScroll = data.OnScroll
+	if data.Position != w.requested {
+		// The new position will be clamped when the bounds are set.
+		w.position = data.Position
+		w.requested = data.Position
+	}
+
+	child, err := base.DiffChild(w.parent, w.child, data.Child)
+	w.child = child
+	return err
+}
@@ -0,0 +1,175 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/internal/syscall"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+type scrollElement struct {
+	handle     *gtk.ScrolledWindow
+	layout     *gtk.Layout
+	child      base.Element
+	childSize  base.Size
+	horizontal bool
+	vertical   bool
+	position   base.Point
+	requested  base.Point
+	pending    bool
+	onScroll   func(base.Point)
+	context    *base.Context
+	bounds     base.Rectangle
+}
+
+func scrollPolicy(value bool) gtk.PolicyType {
+	if value {
+		return gtk.POLICY_AUTOMATIC
+	}
+	return gtk.POLICY_NEVER
+}
+
+func (w *Scroll) mount(parent base.Control) (base.Element, error) {
+	control, err := gtk.ScrolledWindowNew(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	control.SetPolicy(scrollPolicy(w.Horizontal), scrollPolicy(w.Vertical))
+
+	// The child is placed in a layout, so that its controls can be positioned
+	// in the same manner as for the window.
+	layout, err := gtk.LayoutNew(nil, nil)
+	if err != nil {
+		control.Destroy()
+		return nil, err
+	}
+	control.Add(layout)
+	parent.Handle.Add(control)
+
+	retval := &scrollElement{
+		handle:     control,
+		layout:     layout,
+		horizontal: w.Horizontal,
+		vertical:   w.Vertical,
+		position:   w.Position,
+		requested:  w.Position,
+		pending:    w.Position != base.Point{},
+		onScroll:   w.OnScroll,
+		context:    parent.Context,
+	}
+
+	child, err := base.Mount(base.Control{Handle: &layout.Container, Context: parent.Context}, w.Child)
+	if child == nil {
+		control.Destroy()
+		return nil, err
+	}
+	retval.child = child
+
+	control.Connect("destroy", scrollOnDestroy, retval)
+	control.GetHAdjustment().Connect("value-changed", scrollOnValueChanged, retval)
+	control.GetVAdjustment().Connect("value-changed", scrollOnValueChanged, retval)
+	control.ShowAll()
+
+	return retval, err
+}
+
+func scrollOnDestroy(widget *gtk.ScrolledWindow, mounted *scrollElement) {
+	mounted.handle = nil
+}
+
+func scrollOnValueChanged(adjustment *gtk.Adjustment, mounted *scrollElement) {
+	// Scroll events arrive outside of layout, so the DPI is taken from the
+	// window rather than the package variable.
+	dpi := mounted.context.DPI()
+	pos := base.Point{
+		base.FromPixelsXAt(int(mounted.handle.GetHAdjustment().GetValue()), dpi.X),
+		base.FromPixelsYAt(int(mounted.handle.GetVAdjustment().GetValue()), dpi.Y),
+	}
+	// Changes to the position made by setPosition are not reported.
+	if pos == mounted.position {
+		return
+	}
+
+	mounted.position = pos
+	if mounted.onScroll != nil {
+		mounted.onScroll(pos)
+	}
+}
+
+func (w *scrollElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *scrollElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	if w.handle != nil {
+		w.handle.Destroy()
+		w.handle = nil
+	}
+}
+
+func (w *scrollElement) scrollbars() base.Size {
+	// GTK overlays the scrollbars on top of the contents.
+	return base.Size{}
+}
+
+func (w *scrollElement) setHidden(value bool) {
+	w.handle.SetVisible(!value)
+}
+
+func (w *scrollElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	pixels := bounds.Pixels()
+	syscall.SetBounds(&w.handle.Widget, pixels.Min.X, pixels.Min.Y, pixels.Dx(), pixels.Dy())
+
+	// The layout scrolls the child, so the child is positioned relative to
+	// the origin of the layout.
+	width, height := w.childSize.Width.PixelsX(), w.childSize.Height.PixelsY()
+	w.layout.SetSize(uint(width), uint(height))
+	w.child.SetBounds(base.Rectangle{Max: base.Point{w.childSize.Width, w.childSize.Height}})
+
+	if w.pending {
+		w.pending = false
+		w.setPosition(w.clampPosition(w.position, base.Size{bounds.Dx(), bounds.Dy()}))
+	}
+}
+
+func (w *scrollElement) setPosition(pos base.Point) {
+	w.position = pos
+	dpi := w.context.DPI()
+
+	// The range of the adjustments is only updated when the layout is
+	// allocated, so it is extended here so that the value is not clamped.
+	hadj := w.handle.GetHAdjustment()
+	if upper := float64(w.childSize.Width.PixelsXAt(dpi.X)); hadj.GetUpper() < upper {
+		hadj.SetUpper(upper)
+	}
+	hadj.SetValue(float64(pos.X.PixelsXAt(dpi.X)))
+	vadj := w.handle.GetVAdjustment()
+	if upper := float64(w.childSize.Height.PixelsYAt(dpi.Y)); vadj.GetUpper() < upper {
+		vadj.SetUpper(upper)
+	}
+	vadj.SetValue(float64(pos.Y.PixelsYAt(dpi.Y)))
+}
+
+func (w *scrollElement) updateProps(data *Scroll) error {
+	w.horizontal = data.Horizontal
+	w.vertical = data.Vertical
+	w.onScroll = data.OnScroll
+	w.handle.SetPolicy(scrollPolicy(data.Horizontal), scrollPolicy(data.Vertical))
+	if data.Position != w.requested {
+		// The position is applied once the layout has been updated.
+		w.position = data.Position
+		w.requested = data.Position
+		w.pending = true
+	}
+
+	child, err := base.DiffChild(base.Control{Handle: &w.layout.Container, Context: w.context}, w.child, data.Child)
+	w.child = child
+	return err
+}
@@ -0,0 +1,80 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestScrollMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Scroll{},
+		&Scroll{Vertical: true, Child: &Button{Text: "A"}},
+		&Scroll{Horizontal: true, Vertical: true, Child: &Label{Text: "Some text"}},
+	)
+}
+
+func TestScrollClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Scroll{},
+		&Scroll{Vertical: true, Child: &Button{Text: "A"}},
+	)
+}
+
+func TestScrollUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Scroll{},
+		&Scroll{Vertical: true, Child: &Button{Text: "A"}},
+		&Scroll{Horizontal: true, Child: &Label{Text: "Some text"}},
+	}, []base.Widget{
+		&Scroll{Vertical: true, Child: &Button{Text: "A"}},
+		&Scroll{Horizontal: true, Vertical: true, Child: &Label{Text: "Some text"}},
+		&Scroll{},
+	})
+}
+
+func TestScrollLayout(t *testing.T) {
+	cases := []struct {
+		horizontal, vertical bool
+		bc                   base.Constraints
+		child                base.Size
+	}{
+		{false, true, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{50 * DIP, 200 * DIP}},
+		{true, false, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{200 * DIP, 50 * DIP}},
+		{true, true, base.Tight(base.Size{100 * DIP, 100 * DIP}), base.Size{50 * DIP, 200 * DIP}},
+	}
+
+	for i, v := range cases {
+		in := &scrollElement{
+			child:      mock.New(v.child),
+			horizontal: v.horizontal,
+			vertical:   v.vertical,
+		}
+		bars := in.scrollbars()
+
+		// The viewport is limited by the constraints, but the child keeps its
+		// natural size along the axes that scroll, or fills the viewport.
+		want := v.bc.Constrain(base.Size{v.child.Width + bars.Width, v.child.Height + bars.Height})
+		wantChild := base.Size{
+			max(v.child.Width, want.Width-bars.Width),
+			max(v.child.Height, want.Height-bars.Height),
+		}
+		if !v.horizontal {
+			wantChild.Width = v.child.Width
+		}
+		if !v.vertical {
+			wantChild.Height = v.child.Height
+		}
+
+		if size := in.Layout(v.bc); size != want {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, want)
+		}
+		if in.childSize != wantChild {
+			t.Errorf("Incorrect child size on case %d, got %s, want %s", i, in.childSize, wantChild)
+		}
+		if pos := in.clampPosition(base.Point{1000 * DIP, 1000 * DIP}, base.Size{want.Width - bars.Width, want.Height - bars.Height}); !v.horizontal && pos.X != 0 || !v.vertical && pos.Y != 0 {
+			t.Errorf("Incorrect clamped position on case %d, got %v", i, pos)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"syscall"
+	"unsafe"
+
+	"bitbucket.org/rj/goey/base"
+	win2 "bitbucket.org/rj/goey/internal/syscall"
+	"github.com/lxn/win"
+)
+
+var (
+	scroll struct {
+		className []uint16
+		atom      win.ATOM
+	}
+)
+
+func init() {
+	scroll.className = []uint16{'G', 'o', 'e', 'y', 'S', 'c', 'r', 'o', 'l', 'l', 0}
+}
+
+func (w *Scroll) mount(parent base.Control) (base.Element, error) {
+	if scroll.atom == 0 {
+		var wc win.WNDCLASSEX
+		wc.CbSize = uint32(unsafe.Sizeof(wc))
+		wc.HInstance = win.GetModuleHandle(nil)
+		wc.LpfnWndProc = syscall.NewCallback(scrollWindowProc)
+		wc.HCursor = win.LoadCursor(0, (*uint16)(unsafe.Pointer(uintptr(win.IDC_ARROW))))
+		wc.HbrBackground = win.GetSysColorBrush(win.COLOR_3DFACE)
+		wc.LpszClassName = &scroll.className[0]
+
+		atom := win.RegisterClassEx(&wc)
+		if atom == 0 {
+			return nil, syscall.GetLastError()
+		}
+		scroll.atom = atom
+	}
+
+	style := uint32(win.WS_CHILD | win.WS_VISIBLE | win.WS_HSCROLL | win.WS_VSCROLL)
+	hwnd, _, err := createControlWindow(win.WS_EX_CONTROLPARENT, &scroll.className[0], "", style, parent.HWnd)
+	if err != nil {
+		return nil, err
+	}
+
+	retval := &scrollElement{
+		Control:    Control{hWnd: hwnd},
+		horizontal: w.Horizontal,
+		vertical:   w.Vertical,
+		position:   w.Position,
+		requested:  w.Position,
+		onScroll:   w.OnScroll,
+		context:    parent.Context,
+	}
+	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(retval)))
+
+	retval.child, err = base.Mount(base.Control{HWnd: hwnd, Context: parent.Context}, w.Child)
+	if retval.child == nil {
+		win.DestroyWindow(hwnd)
+		return nil, err
+	}
+
+	return retval, err
+}
+
+type scrollElement struct {
+	Control
+	horizontal bool
+	vertical   bool
+	position   base.Point
+	requested  base.Point
+	onScroll   func(base.Point)
+
+	child     base.Element
+	childSize base.Size
+	context   *base.Context
+}
+
+func (w *scrollElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.Control.Close()
+}
+
+func (w *scrollElement) scrollbars() base.Size {
+	// Scrollbars are always shown for the axes that scroll, although they
+	// are disabled when the child fits.
+	size := base.Size{}
+	if w.vertical {
+		size.Width = base.FromPixelsX(int(win.GetSystemMetrics(win.SM_CXVSCROLL)))
+	}
+	if w.horizontal {
+		size.Height = base.FromPixelsY(int(win.GetSystemMetrics(win.SM_CYHSCROLL)))
+	}
+	return size
+}
+
+func (w *scrollElement) SetBounds(bounds base.Rectangle) {
+	// Update background control position
+	w.Control.SetBounds(bounds)
+
+	// Update the scroll bars to match the size of the child, and the size of
+	// the client area.
+	bars := w.scrollbars()
+	viewport := base.Size{bounds.Dx() - bars.Width, bounds.Dy() - bars.Height}
+	w.position = w.clampPosition(w.position, viewport)
+	dpi := w.context.DPI()
+	w.position.X = w.setScrollInfo(win.SB_HORZ, w.horizontal, w.childSize.Width.PixelsXAt(dpi.X), viewport.Width.PixelsXAt(dpi.X), w.position.X.PixelsXAt(dpi.X), dpi.X, base.FromPixelsXAt)
+	w.position.Y = w.setScrollInfo(win.SB_VERT, w.vertical, w.childSize.Height.PixelsYAt(dpi.Y), viewport.Height.PixelsYAt(dpi.Y), w.position.Y.PixelsYAt(dpi.Y), dpi.Y, base.FromPixelsYAt)
+
+	w.setChildBounds()
+}
+
+func (w *scrollElement) setScrollInfo(direction int32, enabled bool, size, page, pos int, dpi int, fromPixels func(int, int) base.Length) base.Length {
+	if !enabled {
+		win2.ShowScrollBar(w.hWnd, uint(direction), win.FALSE)
+		return 0
+	}
+
+	si := win.SCROLLINFO{
+		FMask: win.SIF_PAGE | win.SIF_RANGE | win.SIF_POS | win.SIF_DISABLENOSCROLL,
+		NMin:  0,
+		NMax:  int32(size),
+		NPage: uint32(page),
+		NPos:  int32(pos),
+	}
+	si.CbSize = uint32(unsafe.Sizeof(si))
+	win2.ShowScrollBar(w.hWnd, uint(direction), win.TRUE)
+	win.SetScrollInfo(w.hWnd, direction, &si, true)
+
+	// Windows may adjust the position, so read it back.
+	si.FMask = win.SIF_POS
+	win.GetScrollInfo(w.hWnd, direction, &si)
+	return fromPixels(int(si.NPos), dpi)
+}
+
+func (w *scrollElement) setChildBounds() {
+	// Coordinates for the child are relative to the client area.
+	w.child.SetBounds(base.Rectangle{
+		base.Point{-w.position.X, -w.position.Y},
+		base.Point{w.childSize.Width - w.position.X, w.childSize.Height - w.position.Y},
+	})
+}
+
+func (w *scrollElement) setScrollPos(direction int32, wParam uintptr) {
+	pos, ok := updateScrollPos(w.hWnd, direction, wParam)
+	if !ok {
+		return
+	}
+
+	// Scroll events arrive outside of layout, so the DPI is taken from the
+	// window rather than the package variable.
+	dpi := w.context.DPI()
+	if direction == win.SB_HORZ {
+		w.position.X = base.FromPixelsXAt(int(pos), dpi.X)
+	} else {
+		w.position.Y = base.FromPixelsYAt(int(pos), dpi.Y)
+	}
+	w.setChildBounds()
+
+	rect := win.RECT{}
+	win.GetClientRect(w.hWnd, &rect)
+	win.InvalidateRect(w.hWnd, &rect, true)
+
+	if w.onScroll != nil {
+		w.onScroll(w.position)
+	}
+}
+
+func (w *scrollElement) SetOrder(previous win.HWND) win.HWND {
+	previous = w.Control.SetOrder(previous)
+	w.child.SetOrder(0)
+	return previous
+}
+
+func (w *scrollElement) updateProps(data *Scroll) error {
+	w.horizontal = data.Horizontal
+	w.vertical = data.Vertical
+	w.onScroll = data.OnScroll
+	if data.Position != w.requested {
+		// The new position will be applied when the bounds are set.
+		w.position = data.Position
+		w.requested = data.Position
+	}
+
+	child, err := base.DiffChild(base.Control{HWnd: w.hWnd, Context: w.context}, w.child, data.Child)
+	w.child = child
+	return err
+}
+
+func scrollWindowProc(hwnd win.HWND, msg uint32, wParam uintptr, lParam uintptr) (result uintptr) {
+	switch msg {
+	case win.WM_DESTROY:
+		// Make sure that the data structure on the Go-side does not point to a non-existent
+		// window.
+		scrollGetPtr(hwnd).hWnd = 0
+		// Defer to the old window proc
+
+	case win.WM_HSCROLL:
+		if lParam == 0 {
+			// Message was sent by the standard scroll bar.
+			scrollGetPtr(hwnd).setScrollPos(win.SB_HORZ, wParam)
+		} else {
+			// Message was sent by a child window, such as a slider.
+			win.SendMessage(win.HWND(lParam), win.WM_HSCROLL, wParam, 0)
+		}
+		return 0
+
+	case win.WM_VSCROLL:
+		if lParam == 0 {
+			scrollGetPtr(hwnd).setScrollPos(win.SB_VERT, wParam)
+		} else {
+			win.SendMessage(win.HWND(lParam), win.WM_VSCROLL, wParam, 0)
+		}
+		return 0
+
+	case win.WM_COMMAND:
+		return windowprocWmCommand(wParam, lParam)
+
+	case win.WM_NOTIFY:
+		return windowprocWmNotify(wParam, lParam)
+
+	case win.WM_CTLCOLORSTATIC:
+		win.SetBkMode(win.HDC(wParam), win.TRANSPARENT)
+		return uintptr(win.GetSysColorBrush(win.COLOR_3DFACE))
+	}
+
+	// Let the default window proc handle all other messages
+	return win.DefWindowProc(hwnd, msg, wParam, lParam)
+}
+
+func scrollGetPtr(hwnd win.HWND) *scrollElement {
+	gwl := win.GetWindowLongPtr(hwnd, win.GWLP_USERDATA)
+	if gwl == 0 {
+		panic("Internal error.")
+	}
+
+	ptr := (*scrollElement)(unsafe.Pointer(gwl))
+	if ptr.hWnd != hwnd && ptr.hWnd != 0 {
+		panic("Internal error.")
+	}
+
+	return ptr
+}
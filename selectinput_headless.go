@@ -0,0 +1,86 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type selectinputElement struct {
+	Control
+
+	items    []string
+	value    int
+	unset    bool
+	disabled bool
+	onChange func(int)
+}
+
+func (w *SelectInput) mount(parent base.Control) (base.Element, error) {
+	retval := &selectinputElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+// Select changes the selected item, as if the user made a choice.
+func (w *selectinputElement) Baseline(height base.Length) base.Length {
+	return centeredBaseline(height)
+}
+
+func (w *selectinputElement) Select(value int) {
+	if w.disabled || value < 0 || value >= len(w.items) {
+		return
+	}
+
+	w.value, w.unset = value, false
+	if w.onChange != nil {
+		w.onChange(value)
+	}
+}
+
+func (w *selectinputElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *selectinputElement) MinIntrinsicHeight(width base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 23 * DIP
+}
+
+func (w *selectinputElement) MinIntrinsicWidth(height base.Length) base.Length {
+	width := base.Length(0)
+	for _, v := range w.items {
+		width = max(width, measureText(v).Width)
+	}
+	return max(75*DIP, width.Scale(13, 10))
+}
+
+func (w *selectinputElement) Props() base.Widget {
+	return &SelectInput{
+		Items:    append([]string{}, w.items...),
+		Value:    w.value,
+		Unset:    w.unset,
+		Disabled: w.disabled,
+		OnChange: w.onChange,
+		OnFocus:  w.onFocus,
+		OnBlur:   w.onBlur,
+	}
+}
+
+func (w *selectinputElement) updateProps(data *SelectInput) error {
+	w.items = append(w.items[:0], data.Items...)
+	w.value, w.unset = data.Value, data.Unset
+	if w.unset {
+		w.value = 0
+	}
+	w.disabled = data.Disabled
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -33,7 +36,7 @@ func (w *SelectInput) mount(parent base.Control) (base.Element, error) {
 	control.SetSensitive(!w.Disabled)
 
 	retval := &selectinputElement{
-		Control:  Control{&control.Widget},
+		Control:  Control{handle: &control.Widget},
 		onChange: w.OnChange,
 	}
 
@@ -61,6 +64,10 @@ func selectinputOnDestroy(widget *gtk.ComboBoxText, mounted *selectinputElement)
 	mounted.handle = nil
 }
 
+func (w *selectinputElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *selectinputElement) comboboxtext() *gtk.ComboBoxText {
 	return (*gtk.ComboBoxText)(unsafe.Pointer(w.handle))
 }
@@ -116,7 +123,7 @@ func (w *selectinputElement) TakeFocus() bool {
 		return false
 	}
 
-	control := Control{widget}
+	control := Control{handle: widget}
 	return control.TakeFocus()
 }
 
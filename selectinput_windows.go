@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -42,7 +45,7 @@ func (w *SelectInput) mount(parent base.Control) (base.Element, error) {
 	subclassWindowProcedure(hwnd, &oldComboboxWindowProc, comboboxWindowProc)
 
 	retval := &selectinputElement{
-		Control:       Control{hwnd},
+		Control:       Control{hWnd: hwnd},
 		onChange:      w.OnChange,
 		onFocus:       w.OnFocus,
 		onBlur:        w.OnBlur,
@@ -80,6 +83,10 @@ type selectinputElement struct {
 	preferredWidth base.Length
 }
 
+func (w *selectinputElement) Baseline(height base.Length) base.Length {
+	return w.centeredBaseline(height)
+}
+
 func (w *selectinputElement) Layout(bc base.Constraints) base.Size {
 	width := w.MinIntrinsicWidth(0)
 	height := w.MinIntrinsicHeight(0)
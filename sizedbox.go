@@ -0,0 +1,129 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	sizedboxKind = base.NewKind("bitbucket.org/rj/goey.SizedBox")
+)
+
+// SizedBox describes a widget that gives its child widget a fixed width, a
+// fixed height, or both.
+//
+// If Width or Height is zero, then that dimension is not fixed, and the size
+// of the box will match the child along that axis.  The box will never be
+// sized to violate the constraints from its parent, so a fixed size is only a
+// request.
+type SizedBox struct {
+	Width  base.Length // If greater than zero, the width of the box.
+	Height base.Length // If greater than zero, the height of the box.
+	Child  base.Widget // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*SizedBox) Kind() *base.Kind {
+	return &sizedboxKind
+}
+
+// Mount creates a sized box for a child widget in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *SizedBox) Mount(parent base.Control) (base.Element, error) {
+	// Mount the child
+	child, err := base.Mount(parent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+
+	return &sizedboxElement{
+		parent: parent,
+		child:  child,
+		width:  w.Width,
+		height: w.Height,
+	}, err
+}
+
+type sizedboxElement struct {
+	parent base.Control
+	child  base.Element
+	width  base.Length
+	height base.Length
+	bounds base.Rectangle
+}
+
+func (w *sizedboxElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *sizedboxElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *sizedboxElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*sizedboxElement) Kind() *base.Kind {
+	return &sizedboxKind
+}
+
+func (w *sizedboxElement) Layout(bc base.Constraints) base.Size {
+	if w.width > 0 {
+		bc = bc.TightenWidth(w.width)
+	}
+	if w.height > 0 {
+		bc = bc.TightenHeight(w.height)
+	}
+	return base.Layout(w.child, bc)
+}
+
+func (w *sizedboxElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if w.height > 0 {
+		return w.height
+	}
+	if w.width > 0 {
+		width = w.width
+	}
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *sizedboxElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if w.width > 0 {
+		return w.width
+	}
+	if w.height > 0 {
+		height = w.height
+	}
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *sizedboxElement) Props() base.Widget {
+	return &SizedBox{
+		Width:  w.width,
+		Height: w.height,
+		Child:  base.PropsOf(w.child),
+	}
+}
+
+func (w *sizedboxElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	w.child.SetBounds(bounds)
+}
+
+func (w *sizedboxElement) updateProps(data *SizedBox) (err error) {
+	w.child, err = base.DiffChild(w.parent, w.child, data.Child)
+	w.width = data.Width
+	w.height = data.Height
+	return err
+}
+
+func (w *sizedboxElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*SizedBox))
+}
@@ -0,0 +1,82 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestSizedBoxMount(t *testing.T) {
+	testingMountWidgets(t,
+		&SizedBox{Width: 100 * DIP, Child: &Button{Text: "A"}},
+		&SizedBox{Height: 50 * DIP, Child: &Button{Text: "B"}},
+		&SizedBox{Width: 100 * DIP, Height: 50 * DIP},
+		&SizedBox{},
+	)
+}
+
+func TestSizedBoxClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&SizedBox{Width: 100 * DIP, Child: &Button{Text: "A"}},
+		&SizedBox{},
+	)
+}
+
+func TestSizedBoxUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&SizedBox{Width: 100 * DIP, Child: &Button{Text: "A"}},
+		&SizedBox{Height: 50 * DIP, Child: &Button{Text: "B"}},
+		&SizedBox{},
+	}, []base.Widget{
+		&SizedBox{Height: 50 * DIP, Child: &Button{Text: "AB"}},
+		&SizedBox{},
+		&SizedBox{Width: 100 * DIP, Height: 50 * DIP, Child: &Label{Text: "C"}},
+	})
+}
+
+func TestSizedBoxLayout(t *testing.T) {
+	child := base.Size{20 * DIP, 10 * DIP}
+
+	cases := []struct {
+		width, height base.Length
+		bc            base.Constraints
+		out           base.Size
+	}{
+		{0, 0, base.Loose(base.Size{100 * DIP, 100 * DIP}), child},
+		{50 * DIP, 0, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{50 * DIP, 10 * DIP}},
+		{0, 50 * DIP, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{20 * DIP, 50 * DIP}},
+		{50 * DIP, 40 * DIP, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{50 * DIP, 40 * DIP}},
+		{200 * DIP, 200 * DIP, base.Loose(base.Size{100 * DIP, 100 * DIP}), base.Size{100 * DIP, 100 * DIP}},
+		{50 * DIP, 40 * DIP, base.Tight(base.Size{100 * DIP, 100 * DIP}), base.Size{100 * DIP, 100 * DIP}},
+	}
+
+	for i, v := range cases {
+		elem := sizedboxElement{
+			child:  mock.New(child),
+			width:  v.width,
+			height: v.height,
+		}
+
+		if out := elem.Layout(v.bc); out != v.out {
+			t.Errorf("Case %d: Returned size does not match, got %v, want %v", i, out, v.out)
+		}
+
+		want := v.out
+		if v.width == 0 {
+			want.Width = child.Width
+		}
+		if v.height == 0 {
+			want.Height = child.Height
+		}
+		if v.bc.IsTight() {
+			continue
+		}
+		if out := elem.MinIntrinsicWidth(base.Inf); out != max(want.Width, v.width) {
+			t.Errorf("Case %d: Returned min intrinsic width does not match, got %v, want %v", i, out, max(want.Width, v.width))
+		}
+		if out := elem.MinIntrinsicHeight(base.Inf); out != max(want.Height, v.height) {
+			t.Errorf("Case %d: Returned min intrinsic height does not match, got %v, want %v", i, out, max(want.Height, v.height))
+		}
+	}
+}
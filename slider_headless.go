@@ -0,0 +1,86 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type sliderElement struct {
+	Control
+
+	value    float64
+	disabled bool
+	min, max float64
+	onChange func(float64)
+}
+
+func (w *Slider) mount(parent base.Control) (base.Element, error) {
+	retval := &sliderElement{}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+// SetValue changes the value of the slider, as if moved by the user.
+func (w *sliderElement) SetValue(value float64) {
+	if w.disabled {
+		return
+	}
+
+	if value < w.min {
+		value = w.min
+	} else if value > w.max {
+		value = w.max
+	}
+	if value != w.value {
+		w.value = value
+		if w.onChange != nil {
+			w.onChange(value)
+		}
+	}
+}
+
+func (w *sliderElement) Layout(bc base.Constraints) base.Size {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	width := w.MinIntrinsicWidth(0)
+	if bc.Max.Width > 355*DIP {
+		width = 355 * DIP
+	}
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *sliderElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 24 * DIP
+}
+
+func (w *sliderElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 160 * DIP
+}
+
+func (w *sliderElement) Props() base.Widget {
+	return &Slider{
+		Value:    w.value,
+		Disabled: w.disabled,
+		Min:      w.min,
+		Max:      w.max,
+		OnChange: w.onChange,
+		OnFocus:  w.onFocus,
+		OnBlur:   w.onBlur,
+	}
+}
+
+func (w *sliderElement) updateProps(data *Slider) error {
+	w.value = data.Value
+	w.disabled = data.Disabled
+	w.min, w.max = data.Min, data.Max
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -32,7 +35,7 @@ func (w *Slider) mount(parent base.Control) (base.Element, error) {
 	control.SetSensitive(!w.Disabled)
 
 	retval := &sliderElement{
-		Control:  Control{&control.Widget},
+		Control:  Control{handle: &control.Widget},
 		value:    w.Value,
 		min:      w.Min,
 		max:      w.Max,
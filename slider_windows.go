@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -50,7 +53,7 @@ func (w *Slider) mount(parent base.Control) (base.Element, error) {
 	subclassWindowProcedure(hwnd, &slider.oldWindowProc, sliderWindowProc)
 
 	retval := &sliderElement{
-		Control:      Control{hwnd},
+		Control:      Control{hWnd: hwnd},
 		currentValue: currentValue,
 		min:          w.Min,
 		max:          w.Max,
@@ -0,0 +1,321 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	splitKind = base.NewKind("bitbucket.org/rj/goey.Split")
+)
+
+const (
+	// splitDividerSize is the thickness of the divider between the two
+	// children of a split.
+	splitDividerSize = 6 * DIP
+)
+
+// Split describes a layout widget that divides its space between two child
+// widgets, with a divider between them that the user can drag.  The children
+// are placed side by side, or, if Vertical is set, one above the other.  A
+// typical use is a file tree on one side and an editor on the other.
+//
+// The position of the divider is the width (or height, for a vertical split)
+// of the first child.  The divider cannot be moved so that either child would
+// be smaller than its minimum intrinsic size, and so a position of zero gives
+// the first child its minimum size.  OnChange is called whenever the user
+// moves the divider, and the new position should be stored so that it is
+// preserved when the widget is updated.
+//
+// For a right-to-left layout, a horizontal split places the first child on
+// the right.
+type Split struct {
+	Vertical bool        // If true, the children are placed one above the other.
+	Position base.Length // Size of the first child along the main axis.
+	First    base.Widget // Child widget at the start, either left or top.
+	Second   base.Widget // Child widget at the end, either right or bottom.
+
+	OnChange func(base.Length) // OnChange will be called whenever the user moves the divider.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Split) Kind() *base.Kind {
+	return &splitKind
+}
+
+// Mount creates a split layout for child widgets in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *Split) Mount(parent base.Control) (base.Element, error) {
+	retval := &splitElement{
+		parent:   parent,
+		vertical: w.Vertical,
+		position: w.Position,
+		onChange: w.OnChange,
+	}
+
+	// If the parent's context is set to continue on error, the split is
+	// mounted even if a child failed, provided that the child could still be
+	// created.
+	first, err1 := base.Mount(parent, w.First)
+	if first == nil {
+		return nil, err1
+	}
+	retval.first = first
+
+	err := retval.divider.mount(parent, retval)
+	if err != nil {
+		first.Close()
+		return nil, err
+	}
+
+	second, err2 := base.Mount(parent, w.Second)
+	if second == nil {
+		retval.divider.close()
+		first.Close()
+		return nil, err2
+	}
+	retval.second = second
+
+	if err1 != nil {
+		return retval, err1
+	}
+	return retval, err2
+}
+
+type splitElement struct {
+	parent   base.Control
+	first    base.Element
+	second   base.Element
+	divider  splitDivider
+	vertical bool
+	position base.Length
+	onChange func(base.Length)
+
+	// Position of the divider, and sizes of the children, as determined by
+	// the last layout.
+	dividerPos base.Length
+	firstSize  base.Size
+	secondSize base.Size
+	dragStart  base.Length
+	bounds     base.Rectangle
+}
+
+func (w *splitElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *splitElement) Children() []base.Element {
+	return []base.Element{w.first, w.second}
+}
+
+func (w *splitElement) Close() {
+	w.first.Close()
+	w.divider.close()
+	w.second.Close()
+}
+
+func (*splitElement) Kind() *base.Kind {
+	return &splitKind
+}
+
+// flipSize exchanges the width and height for a vertical split, so that the
+// layout can be calculated as if the children were side by side.
+func (w *splitElement) flipSize(size base.Size) base.Size {
+	if w.vertical {
+		return base.Size{size.Height, size.Width}
+	}
+	return size
+}
+
+func (w *splitElement) flipConstraints(bc base.Constraints) base.Constraints {
+	return base.Constraints{w.flipSize(bc.Min), w.flipSize(bc.Max)}
+}
+
+// minMain returns the minimum size of the child along the main axis.
+func (w *splitElement) minMain(child base.Element, cross base.Length) base.Length {
+	if w.vertical {
+		return child.MinIntrinsicHeight(cross)
+	}
+	return child.MinIntrinsicWidth(cross)
+}
+
+// minCross returns the minimum size of the child along the cross axis.
+func (w *splitElement) minCross(child base.Element, main base.Length) base.Length {
+	if w.vertical {
+		return child.MinIntrinsicWidth(main)
+	}
+	return child.MinIntrinsicHeight(main)
+}
+
+// clampPosition limits the position of the divider, so that neither child is
+// smaller than its minimum size.  If both limits cannot be met, the first
+// child is given priority.
+func (w *splitElement) clampPosition(pos, main, cross base.Length) base.Length {
+	lo := w.minMain(w.first, cross)
+	hi := max(lo, main-splitDividerSize-w.minMain(w.second, cross))
+	return min(pos.Clamp(lo, hi), max(0, main-splitDividerSize))
+}
+
+func (w *splitElement) Layout(bc base.Constraints) base.Size {
+	fbc := w.flipConstraints(bc)
+
+	// The split fills the space available along the main axis.  Otherwise, the
+	// children are given their minimum sizes.
+	main := fbc.Max.Width
+	if main == base.Inf {
+		natural := max(w.position, w.minMain(w.first, fbc.Max.Height))
+		main = fbc.ConstrainWidth(natural + splitDividerSize + w.minMain(w.second, fbc.Max.Height))
+	}
+
+	// Find the size of the children along the cross axis.
+	pos := w.clampPosition(w.position, main, fbc.Max.Height)
+	first := w.flipSize(base.Layout(w.first, w.flipConstraints(base.Constraints{
+		base.Size{pos, fbc.Min.Height},
+		base.Size{pos, fbc.Max.Height},
+	})))
+	second := w.flipSize(base.Layout(w.second, w.flipConstraints(base.Constraints{
+		base.Size{max(0, main-pos-splitDividerSize), fbc.Min.Height},
+		base.Size{max(0, main-pos-splitDividerSize), fbc.Max.Height},
+	})))
+	cross := fbc.ConstrainHeight(max(first.Height, second.Height))
+
+	// Both children fill the cross axis.
+	size := w.flipSize(base.Size{main, cross})
+	w.layoutChildren(size)
+	return size
+}
+
+// layoutChildren updates the position of the divider, and the sizes of the
+// children, so that they fill the split.
+func (w *splitElement) layoutChildren(size base.Size) {
+	fsize := w.flipSize(size)
+	pos := w.clampPosition(w.position, fsize.Width, fsize.Height)
+
+	w.dividerPos = pos
+	w.firstSize = base.Layout(w.first, base.Tight(w.flipSize(base.Size{pos, fsize.Height})))
+	w.secondSize = base.Layout(w.second, base.Tight(w.flipSize(base.Size{max(0, fsize.Width-pos-splitDividerSize), fsize.Height})))
+}
+
+func (w *splitElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if w.vertical {
+		return w.first.MinIntrinsicHeight(width) + splitDividerSize + w.second.MinIntrinsicHeight(width)
+	}
+	return w.minCrossForMain(width)
+}
+
+func (w *splitElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if w.vertical {
+		return w.minCrossForMain(height)
+	}
+	return w.first.MinIntrinsicWidth(height) + splitDividerSize + w.second.MinIntrinsicWidth(height)
+}
+
+// minCrossForMain returns the minimum size along the cross axis, when the
+// split has the specified size along the main axis.
+func (w *splitElement) minCrossForMain(main base.Length) base.Length {
+	if main == base.Inf {
+		return max(w.minCross(w.first, base.Inf), w.minCross(w.second, base.Inf))
+	}
+
+	pos := w.clampPosition(w.position, main, base.Inf)
+	return max(
+		w.minCross(w.first, pos),
+		w.minCross(w.second, max(0, main-pos-splitDividerSize)),
+	)
+}
+
+func (w *splitElement) Props() base.Widget {
+	return &Split{
+		Vertical: w.vertical,
+		Position: w.position,
+		First:    base.PropsOf(w.first),
+		Second:   base.PropsOf(w.second),
+		OnChange: w.onChange,
+	}
+}
+
+func (w *splitElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	if w.firstSize == (base.Size{}) && w.secondSize == (base.Size{}) {
+		// Layout has not been performed, so the position of the divider is
+		// not known.
+		w.layoutChildren(base.Size{bounds.Dx(), bounds.Dy()})
+	}
+
+	first := w.flipSize(w.firstSize)
+	second := w.flipSize(w.secondSize)
+	cross := w.flipSize(base.Size{bounds.Dx(), bounds.Dy()}).Height
+	w.first.SetBounds(w.childBounds(0, first.Width, first.Height))
+	w.divider.setBounds(w.childBounds(w.dividerPos, w.dividerPos+splitDividerSize, cross))
+	start := w.dividerPos + splitDividerSize
+	w.second.SetBounds(w.childBounds(start, start+second.Width, second.Height))
+}
+
+// childBounds converts a span along the main axis, and a size along the cross
+// axis, into a rectangle within the split.
+func (w *splitElement) childBounds(start, end, cross base.Length) base.Rectangle {
+	bounds := w.bounds
+	if w.vertical {
+		return mirrorForDirection(w.parent, bounds, base.Rect(
+			bounds.Min.X, bounds.Min.Y+start, bounds.Min.X+cross, bounds.Min.Y+end,
+		))
+	}
+	return mirrorForDirection(w.parent, bounds, base.Rect(
+		bounds.Min.X+start, bounds.Min.Y, bounds.Min.X+end, bounds.Min.Y+cross,
+	))
+}
+
+// beginDrag is called by the divider when the user starts to drag.
+func (w *splitElement) beginDrag() {
+	w.dragStart = w.dividerPos
+}
+
+// drag is called by the divider when the user drags the divider.  The offset
+// is the distance that the divider has been dragged along the main axis,
+// towards the right or the bottom.
+func (w *splitElement) drag(offset base.Length) {
+	if !w.vertical && w.parent.Context.Direction() == base.RightToLeft {
+		offset = -offset
+	}
+
+	size := base.Size{w.bounds.Dx(), w.bounds.Dy()}
+	fsize := w.flipSize(size)
+	pos := w.clampPosition(w.dragStart+offset, fsize.Width, fsize.Height)
+	if pos == w.dividerPos {
+		return
+	}
+
+	w.position = pos
+	w.layoutChildren(size)
+	w.SetBounds(w.bounds)
+	if w.onChange != nil {
+		w.onChange(pos)
+	}
+}
+
+// setHidden hides or shows the children and the divider.  The divider is not
+// one of the children, and so would otherwise be missed.
+func (w *splitElement) setHidden(value bool) {
+	setHiddenElements(w.first, value)
+	w.divider.setHidden(value)
+	setHiddenElements(w.second, value)
+}
+
+func (w *splitElement) updateProps(data *Split) (err error) {
+	w.vertical = data.Vertical
+	w.position = data.Position
+	w.onChange = data.OnChange
+	w.divider.setVertical(data.Vertical)
+
+	w.first, err = base.DiffChild(w.parent, w.first, data.First)
+	second, err2 := base.DiffChild(w.parent, w.second, data.Second)
+	w.second = second
+	if err == nil {
+		err = err2
+	}
+	return err
+}
+
+func (w *splitElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Split))
+}
@@ -0,0 +1,35 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type splitDivider struct {
+	Control
+}
+
+func (d *splitDivider) mount(parent base.Control, owner *splitElement) error {
+	return nil
+}
+
+func (d *splitDivider) close() {
+	d.Control.Close()
+}
+
+func (d *splitDivider) setBounds(bounds base.Rectangle) {
+	d.Control.SetBounds(bounds)
+}
+
+func (d *splitDivider) setVertical(vertical bool) {
+	// Nothing required when headless.
+}
+
+// Drag moves the divider, as if the user had dragged it the specified
+// distance towards the right or the bottom.
+func (w *splitElement) Drag(offset base.Length) {
+	w.beginDrag()
+	w.drag(offset)
+}
@@ -0,0 +1,142 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/internal/syscall"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+type splitDivider struct {
+	handle    *gtk.EventBox
+	separator *gtk.Separator
+	owner     *splitElement
+	vertical  bool
+	start     float64
+}
+
+func splitOrientation(vertical bool) gtk.Orientation {
+	// The separator runs across the main axis.
+	if vertical {
+		return gtk.ORIENTATION_HORIZONTAL
+	}
+	return gtk.ORIENTATION_VERTICAL
+}
+
+func (d *splitDivider) mount(parent base.Control, owner *splitElement) error {
+	control, err := gtk.EventBoxNew()
+	if err != nil {
+		return err
+	}
+	separator, err := gtk.SeparatorNew(splitOrientation(owner.vertical))
+	if err != nil {
+		control.Destroy()
+		return err
+	}
+	control.Add(separator)
+	parent.Handle.Add(control)
+
+	d.handle = control
+	d.separator = separator
+	d.owner = owner
+	d.vertical = owner.vertical
+
+	control.AddEvents(int(gdk.BUTTON_PRESS_MASK | gdk.BUTTON1_MOTION_MASK))
+	control.Connect("destroy", splitDividerOnDestroy, d)
+	control.Connect("realize", splitDividerOnRealize, d)
+	control.Connect("button-press-event", splitDividerOnButtonPress, d)
+	control.Connect("motion-notify-event", splitDividerOnMotion, d)
+	control.ShowAll()
+
+	return nil
+}
+
+func splitDividerOnDestroy(widget *gtk.EventBox, d *splitDivider) {
+	d.handle = nil
+}
+
+func splitDividerOnRealize(widget *gtk.EventBox, d *splitDivider) {
+	d.updateCursor()
+}
+
+func splitDividerOnButtonPress(widget *gtk.EventBox, event *gdk.Event, d *splitDivider) bool {
+	// Use root coordinates, as the divider moves while it is dragged.
+	button := gdk.EventButtonNewFromEvent(event)
+	if d.vertical {
+		d.start = button.YRoot()
+	} else {
+		d.start = button.XRoot()
+	}
+	d.owner.beginDrag()
+	return true
+}
+
+func splitDividerOnMotion(widget *gtk.EventBox, event *gdk.Event, d *splitDivider) bool {
+	// Input events arrive outside of layout, so the DPI is taken from the
+	// window rather than the package variable.
+	x, y := gdk.EventMotionNewFromEvent(event).MotionValRoot()
+	dpi := d.owner.parent.Context.DPI()
+	if d.vertical {
+		d.owner.drag(base.FromPixelsYAt(int(y-d.start), dpi.Y))
+	} else {
+		d.owner.drag(base.FromPixelsXAt(int(x-d.start), dpi.X))
+	}
+	return true
+}
+
+func (d *splitDivider) updateCursor() {
+	window, err := d.handle.GetWindow()
+	if err != nil || window == nil {
+		return
+	}
+	display, err := gdk.DisplayGetDefault()
+	if err != nil {
+		return
+	}
+
+	name := "col-resize"
+	if d.vertical {
+		name = "row-resize"
+	}
+	cursor, err := gdk.CursorNewFromName(display, name)
+	if err != nil {
+		return
+	}
+	window.SetCursor(cursor)
+}
+
+func (d *splitDivider) close() {
+	if d.handle != nil {
+		d.handle.Destroy()
+		d.handle = nil
+	}
+}
+
+func (d *splitDivider) setBounds(bounds base.Rectangle) {
+	pixels := bounds.Pixels()
+	syscall.SetBounds(&d.handle.Widget, pixels.Min.X, pixels.Min.Y, pixels.Dx(), pixels.Dy())
+}
+
+func (d *splitDivider) setHidden(value bool) {
+	d.handle.SetVisible(!value)
+}
+
+func (d *splitDivider) setVertical(vertical bool) {
+	if d.vertical == vertical {
+		return
+	}
+
+	// Replace the separator so that it has the correct orientation.
+	separator, err := gtk.SeparatorNew(splitOrientation(vertical))
+	if err == nil {
+		d.separator.Destroy()
+		d.handle.Add(separator)
+		separator.Show()
+		d.separator = separator
+	}
+	d.vertical = vertical
+	d.updateCursor()
+}
@@ -0,0 +1,102 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestSplitMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Split{},
+		&Split{First: &Button{Text: "A"}, Second: &Label{Text: "Some text"}},
+		&Split{Vertical: true, Position: 50 * DIP, First: &Button{Text: "A"}, Second: &Button{Text: "B"}},
+	)
+}
+
+func TestSplitClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Split{},
+		&Split{First: &Button{Text: "A"}, Second: &Label{Text: "Some text"}},
+	)
+}
+
+func TestSplitUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Split{},
+		&Split{First: &Button{Text: "A"}, Second: &Label{Text: "Some text"}},
+		&Split{Vertical: true, Position: 50 * DIP, First: &Button{Text: "A"}},
+	}, []base.Widget{
+		&Split{First: &Button{Text: "A"}, Second: &Label{Text: "Some text"}},
+		&Split{Vertical: true, Position: 50 * DIP, First: &Button{Text: "A"}},
+		&Split{},
+	})
+}
+
+func TestSplitLayout(t *testing.T) {
+	cases := []struct {
+		vertical bool
+		position base.Length
+		bc       base.Constraints
+		size     base.Size
+		divider  base.Length
+	}{
+		{false, 40 * DIP, base.Tight(base.Size{100 * DIP, 50 * DIP}), base.Size{100 * DIP, 50 * DIP}, 40 * DIP},
+		{false, 0, base.Tight(base.Size{100 * DIP, 50 * DIP}), base.Size{100 * DIP, 50 * DIP}, 20 * DIP},
+		{false, 90 * DIP, base.Tight(base.Size{100 * DIP, 50 * DIP}), base.Size{100 * DIP, 50 * DIP}, 64 * DIP},
+		{false, 0, base.Loose(base.Size{base.Inf, 50 * DIP}), base.Size{56 * DIP, 15 * DIP}, 20 * DIP},
+		{true, 40 * DIP, base.Tight(base.Size{50 * DIP, 100 * DIP}), base.Size{50 * DIP, 100 * DIP}, 40 * DIP},
+		{true, 0, base.Tight(base.Size{50 * DIP, 100 * DIP}), base.Size{50 * DIP, 100 * DIP}, 10 * DIP},
+		{true, 90 * DIP, base.Tight(base.Size{50 * DIP, 100 * DIP}), base.Size{50 * DIP, 100 * DIP}, 79 * DIP},
+	}
+
+	for i, v := range cases {
+		in := &splitElement{
+			first:    mock.New(base.Size{20 * DIP, 10 * DIP}),
+			second:   mock.New(base.Size{30 * DIP, 15 * DIP}),
+			vertical: v.vertical,
+			position: v.position,
+		}
+
+		if size := in.Layout(v.bc); size != v.size {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, v.size)
+		}
+		if in.dividerPos != v.divider {
+			t.Errorf("Incorrect divider position on case %d, got %s, want %s", i, in.dividerPos, v.divider)
+		}
+
+		// The children fill the split, except for the divider.
+		want1 := base.Size{v.divider, v.size.Height}
+		want2 := base.Size{v.size.Width - v.divider - splitDividerSize, v.size.Height}
+		if v.vertical {
+			want1 = base.Size{v.size.Width, v.divider}
+			want2 = base.Size{v.size.Width, v.size.Height - v.divider - splitDividerSize}
+		}
+		if in.firstSize != want1 || in.secondSize != want2 {
+			t.Errorf("Incorrect child sizes on case %d, got %s and %s, want %s and %s", i, in.firstSize, in.secondSize, want1, want2)
+		}
+	}
+}
+
+func TestSplitMinIntrinsic(t *testing.T) {
+	in := &splitElement{
+		first:  mock.New(base.Size{20 * DIP, 10 * DIP}),
+		second: mock.New(base.Size{30 * DIP, 15 * DIP}),
+	}
+
+	if value := in.MinIntrinsicWidth(base.Inf); value != 56*DIP {
+		t.Errorf("Incorrect min intrinsic width, got %s, want %s", value, 56*DIP)
+	}
+	if value := in.MinIntrinsicHeight(base.Inf); value != 15*DIP {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, 15*DIP)
+	}
+
+	in.vertical = true
+	if value := in.MinIntrinsicWidth(base.Inf); value != 30*DIP {
+		t.Errorf("Incorrect min intrinsic width, got %s, want %s", value, 30*DIP)
+	}
+	if value := in.MinIntrinsicHeight(base.Inf); value != 31*DIP {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, 31*DIP)
+	}
+}
@@ -0,0 +1,150 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"syscall"
+	"unsafe"
+
+	"bitbucket.org/rj/goey/base"
+	"github.com/lxn/win"
+)
+
+var (
+	splitter struct {
+		className []uint16
+		atom      win.ATOM
+	}
+)
+
+func init() {
+	splitter.className = []uint16{'G', 'o', 'e', 'y', 'S', 'p', 'l', 'i', 't', 't', 'e', 'r', 0}
+}
+
+type splitDivider struct {
+	Control
+	owner    *splitElement
+	vertical bool
+	dragging bool
+	start    win.POINT
+}
+
+func (d *splitDivider) mount(parent base.Control, owner *splitElement) error {
+	if splitter.atom == 0 {
+		var wc win.WNDCLASSEX
+		wc.CbSize = uint32(unsafe.Sizeof(wc))
+		wc.HInstance = win.GetModuleHandle(nil)
+		wc.LpfnWndProc = syscall.NewCallback(splitterWindowProc)
+		wc.HbrBackground = win.GetSysColorBrush(win.COLOR_3DFACE)
+		wc.LpszClassName = &splitter.className[0]
+
+		atom := win.RegisterClassEx(&wc)
+		if atom == 0 {
+			return syscall.GetLastError()
+		}
+		splitter.atom = atom
+	}
+
+	style := uint32(win.WS_CHILD | win.WS_VISIBLE)
+	hwnd, _, err := createControlWindow(0, &splitter.className[0], "", style, parent.HWnd)
+	if err != nil {
+		return err
+	}
+
+	d.hWnd = hwnd
+	d.owner = owner
+	d.vertical = owner.vertical
+	win.SetWindowLongPtr(hwnd, win.GWLP_USERDATA, uintptr(unsafe.Pointer(d)))
+	return nil
+}
+
+func (d *splitDivider) close() {
+	d.Control.Close()
+}
+
+func (d *splitDivider) setBounds(bounds base.Rectangle) {
+	d.Control.SetBounds(bounds)
+}
+
+func (d *splitDivider) setVertical(vertical bool) {
+	d.vertical = vertical
+}
+
+func (w *splitElement) SetOrder(previous win.HWND) win.HWND {
+	previous = w.first.SetOrder(previous)
+	previous = w.divider.SetOrder(previous)
+	return w.second.SetOrder(previous)
+}
+
+func splitterWindowProc(hwnd win.HWND, msg uint32, wParam uintptr, lParam uintptr) (result uintptr) {
+	switch msg {
+	case win.WM_DESTROY:
+		// Make sure that the data structure on the Go-side does not point to a non-existent
+		// window.
+		splitterGetPtr(hwnd).hWnd = 0
+		// Defer to the old window proc
+
+	case win.WM_SETCURSOR:
+		if win.LOWORD(uint32(lParam)) == win.HTCLIENT {
+			cursor := win.IDC_SIZEWE
+			if splitterGetPtr(hwnd).vertical {
+				cursor = win.IDC_SIZENS
+			}
+			win.SetCursor(win.LoadCursor(0, (*uint16)(unsafe.Pointer(uintptr(cursor)))))
+			return win.TRUE
+		}
+		// Defer to the old window proc
+
+	case win.WM_LBUTTONDOWN:
+		d := splitterGetPtr(hwnd)
+		d.dragging = true
+		win.GetCursorPos(&d.start)
+		win.SetCapture(hwnd)
+		d.owner.beginDrag()
+		return 0
+
+	case win.WM_MOUSEMOVE:
+		if d := splitterGetPtr(hwnd); d.dragging {
+			// Use screen coordinates, as the divider moves while it is
+			// dragged.  The DPI is taken from the window, as the package
+			// variable is only valid during layout.
+			pt := win.POINT{}
+			win.GetCursorPos(&pt)
+			dpi := d.owner.parent.Context.DPI()
+			if d.vertical {
+				d.owner.drag(base.FromPixelsYAt(int(pt.Y-d.start.Y), dpi.Y))
+			} else {
+				d.owner.drag(base.FromPixelsXAt(int(pt.X-d.start.X), dpi.X))
+			}
+		}
+		return 0
+
+	case win.WM_LBUTTONUP:
+		if splitterGetPtr(hwnd).dragging {
+			win.ReleaseCapture()
+		}
+		return 0
+
+	case win.WM_CAPTURECHANGED:
+		splitterGetPtr(hwnd).dragging = false
+		return 0
+	}
+
+	// Let the default window proc handle all other messages
+	return win.DefWindowProc(hwnd, msg, wParam, lParam)
+}
+
+func splitterGetPtr(hwnd win.HWND) *splitDivider {
+	gwl := win.GetWindowLongPtr(hwnd, win.GWLP_USERDATA)
+	if gwl == 0 {
+		panic("Internal error.")
+	}
+
+	ptr := (*splitDivider)(unsafe.Pointer(gwl))
+	if ptr.hWnd != hwnd && ptr.hWnd != 0 {
+		panic("Internal error.")
+	}
+
+	return ptr
+}
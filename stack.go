@@ -0,0 +1,227 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	stackKind = base.NewKind("bitbucket.org/rj/goey.Stack")
+)
+
+// StackChild describes the position of a child widget in a stack.
+type StackChild struct {
+	HAlign Alignment   // Horizontal alignment of the child widget.
+	VAlign Alignment   // Vertical alignment of the child widget.
+	Insets Insets      // Space between the edges of the stack and the child widget.
+	Fill   bool        // If true, the child is stretched to fill the stack, less the insets.
+	Child  base.Widget // Child widget.
+}
+
+// Stack describes a layout widget that places its child widgets on top of
+// each other.  Later children are drawn above earlier children, so that a
+// stack can be used to place a badge over an image, or a message over a form.
+//
+// Each child is first inset from the edges of the stack, and then positioned
+// within the remaining space using HAlign and VAlign, in the same manner as
+// for Align.  If Fill is set, the child is instead stretched to fill the
+// remaining space.  For a right-to-left layout, the horizontal position is
+// mirrored.
+//
+// The size of the stack is the smallest that will hold all of its children,
+// including their insets.
+type Stack struct {
+	Children []StackChild // Children, from the bottom to the top.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Stack) Kind() *base.Kind {
+	return &stackKind
+}
+
+// Mount creates a stacked layout for child widgets in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *Stack) Mount(parent base.Control) (base.Element, error) {
+	// Mount all of the children.  If the parent's context is set to continue
+	// on error, the stack is mounted even if some of the children failed.
+	c, err := base.DiffChildren(parent, nil, stackChildWidgets(w.Children))
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
+	}
+
+	return &stackElement{
+		parent:   parent,
+		children: c,
+		items:    updateStackItems(c, w.Children, nil),
+	}, err
+}
+
+func stackChildWidgets(items []StackChild) []base.Widget {
+	if len(items) == 0 {
+		return nil
+	}
+
+	widgets := make([]base.Widget, 0, len(items))
+	for _, v := range items {
+		widgets = append(widgets, v.Child)
+	}
+	return widgets
+}
+
+type stackItemInfo struct {
+	props StackChild // Properties as provided, but without the child.
+	size  base.Size
+}
+
+func updateStackItems(children []base.Element, items []StackChild, info []stackItemInfo) []stackItemInfo {
+	if len(children) <= cap(info) {
+		info = info[:len(children)]
+	} else {
+		info = make([]stackItemInfo, len(children))
+	}
+
+	for i := range info {
+		// If the reconciliation stopped after an error, there may be more
+		// children than items.  The extra children use the default
+		// properties.
+		info[i] = stackItemInfo{}
+		if i < len(items) {
+			info[i].props = items[i]
+			info[i].props.Child = nil
+		}
+	}
+	return info
+}
+
+type stackElement struct {
+	parent   base.Control
+	children []base.Element
+	items    []stackItemInfo
+	bounds   base.Rectangle
+}
+
+func (w *stackElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *stackElement) Children() []base.Element {
+	return w.children
+}
+
+func (w *stackElement) Close() {
+	base.CloseElements(w.children)
+	w.children = nil
+	w.items = nil
+}
+
+func (*stackElement) Kind() *base.Kind {
+	return &stackKind
+}
+
+func (w *stackElement) Layout(bc base.Constraints) base.Size {
+	// Each child can use as much space as is available, less its insets.
+	size := base.Size{}
+	for i, v := range w.children {
+		insets := &w.items[i].props.Insets
+		cbc := bc.Loosen().Inset(insets.Left+insets.Right, insets.Top+insets.Bottom)
+		childSize := base.Layout(v, cbc)
+		w.items[i].size = childSize
+		size.Width = max(size.Width, childSize.Width+insets.Left+insets.Right)
+		size.Height = max(size.Height, childSize.Height+insets.Top+insets.Bottom)
+	}
+	size = bc.Constrain(size)
+
+	// Children that fill the stack need to match its final size.
+	for i, v := range w.children {
+		if item := &w.items[i]; item.props.Fill {
+			insets := &item.props.Insets
+			item.size = base.Layout(v, base.Tight(base.Size{
+				max(0, size.Width-insets.Left-insets.Right),
+				max(0, size.Height-insets.Top-insets.Bottom),
+			}))
+		}
+	}
+
+	return size
+}
+
+func (w *stackElement) MinIntrinsicHeight(width base.Length) base.Length {
+	size := base.Length(0)
+	for i, v := range w.children {
+		insets := &w.items[i].props.Insets
+		height := v.MinIntrinsicHeight(guardInf(width, max(0, width-insets.Left-insets.Right)))
+		size = max(size, height+insets.Top+insets.Bottom)
+	}
+	return size
+}
+
+func (w *stackElement) MinIntrinsicWidth(height base.Length) base.Length {
+	size := base.Length(0)
+	for i, v := range w.children {
+		insets := &w.items[i].props.Insets
+		width := v.MinIntrinsicWidth(guardInf(height, max(0, height-insets.Top-insets.Bottom)))
+		size = max(size, width+insets.Left+insets.Right)
+	}
+	return size
+}
+
+func (w *stackElement) Props() base.Widget {
+	children := []StackChild(nil)
+	if len(w.children) != 0 {
+		children = make([]StackChild, 0, len(w.children))
+		for i, v := range w.children {
+			item := w.items[i].props
+			item.Child = base.PropsOf(v)
+			children = append(children, item)
+		}
+	}
+
+	return &Stack{
+		Children: children,
+	}
+}
+
+func (w *stackElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+
+	for i, v := range w.children {
+		item := &w.items[i]
+		area := base.Rectangle{
+			base.Point{bounds.Min.X + item.props.Insets.Left, bounds.Min.Y + item.props.Insets.Top},
+			base.Point{bounds.Max.X - item.props.Insets.Right, bounds.Max.Y - item.props.Insets.Bottom},
+		}
+		if !item.props.Fill {
+			x := alignPosition(item.props.HAlign, area.Min.X, area.Max.X, item.size.Width)
+			y := alignPosition(item.props.VAlign, area.Min.Y, area.Max.Y, item.size.Height)
+			area = base.Rectangle{
+				base.Point{x, y},
+				base.Point{x + item.size.Width, y + item.size.Height},
+			}
+		}
+		v.SetBounds(mirrorForDirection(w.parent, bounds, area))
+	}
+}
+
+func (w *stackElement) updateProps(data *Stack) (err error) {
+	// Keep a copy of the previous children, which will be required to find
+	// any children that need to be raised.
+	previous := append([]base.Element(nil), w.children...)
+
+	w.children, err = base.DiffChildren(w.parent, w.children, stackChildWidgets(data.Children))
+	w.items = updateStackItems(w.children, data.Children, w.items)
+
+	// Native controls are drawn in the order that they were created.  Any
+	// child that has been mounted, or moved, needs to be raised, as do all of
+	// the children above it.
+	for i, v := range w.children {
+		if i >= len(previous) || previous[i] != v {
+			w.raise(w.children[i:])
+			break
+		}
+	}
+	return err
+}
+
+func (w *stackElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Stack))
+}
@@ -0,0 +1,12 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+func (w *stackElement) raise(children []base.Element) {
+	// There are no native controls to reorder when headless.
+}
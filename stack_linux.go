@@ -0,0 +1,35 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// raise moves the native controls for the children to the top of the
+// parent's drawing order, in order.  A GtkLayout draws its children in the
+// order that they were added, so each control is removed and then added again.
+func (w *stackElement) raise(children []base.Element) {
+	container := w.parent.Handle
+	for _, v := range children {
+		base.Walk(v, func(elem base.Element) bool {
+			handle, ok := elem.(interface{ Handle() *gtk.Widget })
+			if !ok {
+				// Containers without a native control need to be searched
+				// for their children.
+				return true
+			}
+
+			widget := handle.Handle()
+			// The container holds the only reference to the control, so an
+			// extra reference is required while it is removed.
+			widget.Ref()
+			container.Remove(widget)
+			container.Add(widget)
+			widget.Unref()
+			return false
+		})
+	}
+}
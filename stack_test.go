@@ -0,0 +1,158 @@
+package goey
+
+import (
+	"errors"
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func testingStackBadge() []StackChild {
+	return []StackChild{
+		{Child: &Button{Text: "A"}},
+		{HAlign: AlignEnd, VAlign: AlignStart, Child: &Label{Text: "1"}},
+	}
+}
+
+func TestStackMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Stack{},
+		&Stack{Children: testingStackBadge()},
+		&Stack{Children: []StackChild{
+			{Child: &Button{Text: "A"}},
+			{Fill: true, Insets: UniformInsets(5 * DIP), Child: &Label{Text: "Loading..."}},
+		}},
+	)
+}
+
+func TestStackClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Stack{},
+		&Stack{Children: testingStackBadge()},
+	)
+}
+
+func TestStackUpdateProps(t *testing.T) {
+	overlay := []StackChild{
+		{Child: &Label{Text: "Loading..."}},
+		{Child: &Button{Text: "A"}},
+		{Fill: true, Insets: UniformInsets(5 * DIP), Child: &Label{Text: "Loading..."}},
+	}
+
+	testingUpdateWidgets(t, []base.Widget{
+		&Stack{},
+		&Stack{Children: testingStackBadge()},
+		&Stack{Children: overlay},
+	}, []base.Widget{
+		&Stack{Children: testingStackBadge()},
+		&Stack{Children: overlay},
+		&Stack{},
+	})
+}
+
+func TestStackUpdatePropsError(t *testing.T) {
+	err := errors.New("Mock error 1")
+	size := base.Size{20 * DIP, 10 * DIP}
+
+	elem, mountErr := (&Stack{Children: []StackChild{
+		{Child: &mock.Widget{Key: "a", Size: size}},
+		{Child: &mock.Widget{Key: "b", Size: size}},
+		{Child: &mock.Widget{Key: "c", Size: size}},
+	}}).Mount(base.Control{})
+	if mountErr != nil {
+		t.Fatalf("Failed to mount, %s", mountErr)
+	}
+	defer elem.Close()
+
+	// A failed update leaves the unmatched children in place, and the stack
+	// must still be usable.
+	if got := elem.UpdateProps(&Stack{Children: []StackChild{
+		{Child: &mock.Widget{Key: "a", Err: err}},
+	}}); got != err {
+		t.Errorf("Incorrect error on update, got %v, want %v", got, err)
+	}
+	in := elem.(*stackElement)
+	if got := len(in.items); got != len(in.children) {
+		t.Errorf("Incorrect number of items, got %d, want %d", got, len(in.children))
+	}
+	size = in.Layout(base.Loose(base.Size{200 * DIP, 100 * DIP}))
+	in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+	in.MinIntrinsicHeight(base.Inf)
+	in.MinIntrinsicWidth(base.Inf)
+	if got := len(in.Props().(*Stack).Children); got != len(in.children) {
+		t.Errorf("Incorrect number of children in props, got %d, want %d", got, len(in.children))
+	}
+}
+
+func testingStackElement(parent base.Control) (*stackElement, []base.Element) {
+	children := mock.NewList(
+		base.Size{20 * DIP, 10 * DIP},
+		base.Size{10 * DIP, 10 * DIP},
+		base.Size{5 * DIP, 6 * DIP},
+	)
+	items := []StackChild{
+		{},
+		{HAlign: AlignEnd, VAlign: AlignStart, Insets: Insets{Top: 2 * DIP, Right: 2 * DIP}},
+		{Fill: true, Insets: UniformInsets(5 * DIP)},
+	}
+	return &stackElement{
+		parent:   parent,
+		children: children,
+		items:    updateStackItems(children, items, nil),
+	}, children
+}
+
+func TestStackLayout(t *testing.T) {
+	cases := []struct {
+		direction base.Direction
+		bounds    []base.Rectangle
+	}{
+		{base.LeftToRight, []base.Rectangle{
+			base.Rect(0, 3*DIP, 20*DIP, 13*DIP),
+			base.Rect(8*DIP, 2*DIP, 18*DIP, 12*DIP),
+			base.Rect(5*DIP, 5*DIP, 15*DIP, 11*DIP),
+		}},
+		{base.RightToLeft, []base.Rectangle{
+			base.Rect(0, 3*DIP, 20*DIP, 13*DIP),
+			base.Rect(2*DIP, 2*DIP, 12*DIP, 12*DIP),
+			base.Rect(5*DIP, 5*DIP, 15*DIP, 11*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in, children := testingStackElement(base.Control{
+			Context: (*base.Context)(nil).WithDirection(v.direction),
+		})
+
+		size := in.Layout(base.Loose(base.Size{100 * DIP, 100 * DIP}))
+		if want := (base.Size{20 * DIP, 16 * DIP}); size != want {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, want)
+		}
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+func TestStackMinIntrinsic(t *testing.T) {
+	in, _ := testingStackElement(base.Control{})
+
+	if value := in.MinIntrinsicHeight(base.Inf); value != 16*DIP {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, 16*DIP)
+	}
+	if value := in.MinIntrinsicWidth(base.Inf); value != 20*DIP {
+		t.Errorf("Incorrect min intrinsic width, got %s, want %s", value, 20*DIP)
+	}
+
+	empty := stackElement{}
+	if value := empty.MinIntrinsicHeight(base.Inf); value != 0 {
+		t.Errorf("Incorrect min intrinsic height, got %s, want %s", value, base.Length(0))
+	}
+	if value := empty.MinIntrinsicWidth(base.Inf); value != 0 {
+		t.Errorf("Incorrect min intrinsic width, got %s, want %s", value, base.Length(0))
+	}
+}
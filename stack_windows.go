@@ -0,0 +1,23 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+	"github.com/lxn/win"
+)
+
+func (w *stackElement) raise(children []base.Element) {
+	// The z-order is updated by SetOrder, which is called by the window
+	// after any update.
+}
+
+func (w *stackElement) SetOrder(previous win.HWND) win.HWND {
+	// Controls earlier in the z-order are drawn on top, so the children are
+	// ordered from the top of the stack to the bottom.
+	for i := len(w.children) - 1; i >= 0; i-- {
+		previous = w.children[i].SetOrder(previous)
+	}
+	return previous
+}
@@ -57,6 +57,13 @@ func (w *Tabs) UpdateValue() {
 	}
 }
 
+func (w *tabsElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
 func (*tabsElement) Kind() *base.Kind {
 	return &tabsKind
 }
@@ -73,7 +80,7 @@ func (w *tabsElement) Layout(bc base.Constraints) base.Size {
 		})
 	}
 
-	size := w.child.Layout(bc.Inset(insets.X, insets.Y))
+	size := base.Layout(w.child, bc.Inset(insets.X, insets.Y))
 	return base.Size{
 		Width:  size.Width + insets.X,
 		Height: size.Height + insets.Y,
@@ -0,0 +1,151 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type tabsElement struct {
+	Control
+	parent   base.Control
+	value    int
+	child    base.Element
+	widgets  []TabItem
+	insets   Insets
+	onChange func(int)
+}
+
+func (w *Tabs) mount(parent base.Control) (base.Element, error) {
+	child := base.Element(nil)
+	if len(w.Children) > 0 {
+		child_, err := base.Mount(parent, w.Children[w.Value].Child)
+		if err != nil {
+			return nil, err
+		}
+		child = child_
+	}
+
+	retval := &tabsElement{
+		parent:   parent,
+		value:    w.Value,
+		child:    child,
+		widgets:  w.Children,
+		insets:   w.Insets,
+		onChange: w.OnChange,
+	}
+	return retval, nil
+}
+
+func (w *tabsElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+	w.Control.Close()
+}
+
+func (w *tabsElement) controlInsets() base.Point {
+	// Space for a single row of tabs, with no border.
+	return base.Point{
+		X: 0,
+		Y: 23 * DIP,
+	}
+}
+
+func (w *tabsElement) controlTabsMinWidth() base.Length {
+	width := base.Length(0)
+	for _, v := range w.widgets {
+		width += measureText(v.Caption).Width + 12*DIP
+	}
+	return width
+}
+
+// SelectTab changes the selected tab, as if the user clicked on its caption.
+func (w *tabsElement) SelectTab(page int) error {
+	if page == w.value || page < 0 || page >= len(w.widgets) {
+		return nil
+	}
+
+	if w.onChange != nil {
+		w.onChange(page)
+	}
+	if page == w.value {
+		return nil
+	}
+
+	child, err := base.DiffChild(w.parent, w.child, w.widgets[page].Child)
+	w.child = child
+	w.value = page
+	if err != nil {
+		return err
+	}
+	if w.child != nil {
+		bounds := w.childBounds()
+		base.Layout(w.child, base.Tight(base.Size{
+			Width:  bounds.Dx(),
+			Height: bounds.Dy(),
+		}))
+		w.child.SetBounds(bounds)
+	}
+	return nil
+}
+
+func (w *tabsElement) Props() base.Widget {
+	children := make([]TabItem, len(w.widgets))
+	copy(children, w.widgets)
+	if w.child != nil && w.value < len(children) {
+		// Only the current tab is mounted.
+		children[w.value].Child = base.PropsOf(w.child)
+	}
+
+	return &Tabs{
+		Value:    w.value,
+		Children: children,
+		Insets:   w.insets,
+		OnChange: w.onChange,
+	}
+}
+
+func (w *tabsElement) childBounds() base.Rectangle {
+	insets := w.insets.forDirection(w.parent.Context)
+	bounds := w.bounds
+	bounds.Min.X += insets.Left
+	bounds.Min.Y += w.controlInsets().Y + insets.Top
+	bounds.Max.X -= insets.Right
+	bounds.Max.Y -= insets.Bottom
+	return bounds
+}
+
+func (w *tabsElement) SetBounds(bounds base.Rectangle) {
+	w.Control.SetBounds(bounds)
+
+	if w.child != nil {
+		w.child.SetBounds(w.childBounds())
+	}
+}
+
+func (w *tabsElement) updateProps(data *Tabs) error {
+	w.widgets = data.Children
+	w.onChange = data.OnChange
+
+	// Update the selected widget
+	if data.Value >= 0 {
+		w.value = data.Value
+	}
+	if w.value >= len(w.widgets) {
+		w.value = len(w.widgets) - 1
+	}
+	if w.value < 0 {
+		if w.child != nil {
+			w.child.Close()
+			w.child = nil
+		}
+		return nil
+	}
+
+	child, err := base.DiffChild(w.parent, w.child, w.widgets[w.value].Child)
+	w.child = child
+	return err
+}
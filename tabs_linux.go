@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -14,10 +17,12 @@ type tabsElement struct {
 	widgets  []TabItem
 	insets   Insets
 	onChange func(int)
+	context  *base.Context
 
 	cachedInsets base.Point
 	cachedBounds base.Rectangle
 	cachedTabsW  base.Length
+	bounds       base.Rectangle
 }
 
 func tabsAppendChildren(handle *gtk.Notebook, children []TabItem) error {
@@ -59,7 +64,7 @@ func (w *Tabs) mount(parent base.Control) (base.Element, error) {
 
 	child := base.Element(nil)
 	if len(w.Children) > 0 {
-		parent := getTabParent(control, w.Value)
+		parent := getTabParent(control, w.Value, parent.Context)
 		child_, err := base.Mount(parent, w.Children[w.Value].Child)
 		if err != nil {
 			control.Destroy()
@@ -76,6 +81,7 @@ func (w *Tabs) mount(parent base.Control) (base.Element, error) {
 		widgets:  w.Children,
 		insets:   w.Insets,
 		onChange: w.OnChange,
+		context:  parent.Context,
 	}
 
 	control.Connect("destroy", tabsOnDestroy, retval)
@@ -113,13 +119,17 @@ func getTabLabel(book *gtk.Notebook, ndx int) *gtk.Label {
 	return (*gtk.Label)(unsafe.Pointer(label))
 }
 
-func getTabParent(book *gtk.Notebook, ndx int) base.Control {
+func getTabParent(book *gtk.Notebook, ndx int, context *base.Context) base.Control {
 	widget, err := book.GetNthPage(ndx)
 	if err != nil {
 		panic(err)
 	}
 	layout := (*gtk.Layout)(unsafe.Pointer(widget))
-	return base.Control{&layout.Container}
+	return base.Control{Handle: &layout.Container, Context: context}
+}
+
+func (w *tabsElement) Bounds() base.Rectangle {
+	return w.bounds
 }
 
 func (w *tabsElement) Close() {
@@ -152,12 +162,12 @@ func (w *tabsElement) controlTabsMinWidth() base.Length {
 }
 
 func (w *tabsElement) mountPage(page int) error {
-	parent := getTabParent(w.handle, page)
-	child, err := w.widgets[page].Child.Mount(parent)
+	parent := getTabParent(w.handle, page, w.context)
+	child, err := base.Mount(parent, w.widgets[page].Child)
 	if err != nil {
 		return err
 	}
-	child.Layout(base.Tight(base.Size{
+	base.Layout(child, base.Tight(base.Size{
 		Width:  w.cachedBounds.Dx(),
 		Height: w.cachedBounds.Dy(),
 	}))
@@ -179,16 +189,26 @@ func (w *tabsElement) Props() base.Widget {
 		children[i].Caption = text
 		children[i].Child = w.widgets[i].Child
 	}
+	if w.child != nil && w.value < count {
+		// Only the current tab is mounted.
+		children[w.value].Child = base.PropsOf(w.child)
+	}
 
 	return &Tabs{
 		Value:    w.value,
 		Children: children,
+		Insets:   w.insets,
 		OnChange: w.onChange,
 	}
 }
 
+func (w *tabsElement) setHidden(value bool) {
+	w.handle.SetVisible(!value)
+}
+
 func (w *tabsElement) SetBounds(bounds base.Rectangle) {
-	handle := Control{&w.handle.Widget}
+	w.bounds = bounds
+	handle := Control{handle: &w.handle.Widget}
 	handle.SetBounds(bounds)
 
 	if w.child != nil {
@@ -201,7 +221,7 @@ func (w *tabsElement) SetBounds(bounds base.Rectangle) {
 		}
 
 		// Offset
-		offset := base.Point{w.insets.Left, w.insets.Top}
+		offset := base.Point{w.insets.forDirection(w.context).Left, w.insets.Top}
 		bounds.Min = bounds.Min.Add(offset)
 		bounds.Max = bounds.Max.Add(offset)
 
@@ -240,7 +260,7 @@ func (w *tabsElement) updateProps(data *Tabs) error {
 	if data.Value == w.value {
 		w.mountPage(data.Value)
 	} else {
-		parent := getTabParent(w.handle, data.Value)
+		parent := getTabParent(w.handle, data.Value, w.context)
 		child, err := base.DiffChild(parent, w.child, data.Children[data.Value].Child)
 		w.child = child
 		if err != nil {
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -52,9 +55,9 @@ func (w *Tabs) mount(parent base.Control) (base.Element, error) {
 	if len(w.Children) > 0 {
 		err := error(nil)
 		if w.Value >= 0 {
-			child, err = base.Mount(base.Control{hwnd}, w.Children[w.Value].Child)
+			child, err = base.Mount(base.Control{HWnd: hwnd, Context: parent.Context}, w.Children[w.Value].Child)
 		} else {
-			child, err = base.Mount(base.Control{hwnd}, w.Children[0].Child)
+			child, err = base.Mount(base.Control{HWnd: hwnd, Context: parent.Context}, w.Children[0].Child)
 		}
 		if err != nil {
 			win.DestroyWindow(hwnd)
@@ -63,7 +66,7 @@ func (w *Tabs) mount(parent base.Control) (base.Element, error) {
 	}
 
 	retval := &tabsElement{
-		Control:  Control{hwnd},
+		Control:  Control{hWnd: hwnd},
 		child:    child,
 		parent:   parent,
 		value:    w.Value,
@@ -127,10 +130,15 @@ func (w *tabsElement) Props() base.Widget {
 		children[i].Caption = syscall.UTF16ToString(text[:])
 		children[i].Child = w.widgets[i].Child
 	}
+	if w.child != nil && uintptr(w.value) < count {
+		// Only the current tab is mounted.
+		children[w.value].Child = base.PropsOf(w.child)
+	}
 
 	return &Tabs{
 		Value:    int(win.SendMessage(w.hWnd, win.TCM_GETCURSEL, 0, 0)),
 		Children: children,
+		Insets:   w.insets,
 		OnChange: w.onChange,
 	}
 }
@@ -159,10 +167,11 @@ func (w *tabsElement) SetBounds(bounds base.Rectangle) {
 			Max: bounds.Max.Add(base.Point{base.FromPixelsX(int(rect.Right)), base.FromPixelsY(int(rect.Bottom))}),
 		}
 		// Offset to handle insets
-		w.cachedBounds.Min.X += w.insets.Left - bounds.Min.X
-		w.cachedBounds.Min.Y += w.insets.Top - bounds.Min.Y
-		w.cachedBounds.Max.X -= w.insets.Right + bounds.Min.X
-		w.cachedBounds.Max.Y -= w.insets.Bottom + bounds.Min.Y
+		insets := w.insets.forDirection(w.parent.Context)
+		w.cachedBounds.Min.X += insets.Left - bounds.Min.X
+		w.cachedBounds.Min.Y += insets.Top - bounds.Min.Y
+		w.cachedBounds.Max.X -= insets.Right + bounds.Min.X
+		w.cachedBounds.Max.Y -= insets.Bottom + bounds.Min.Y
 
 		// Update bounds for the child
 		w.child.SetBounds(w.cachedBounds)
@@ -365,7 +374,7 @@ func tabsWindowProc(hwnd win.HWND, msg uint32, wParam uintptr, lParam uintptr) (
 							}
 							if child != nil {
 								child.SetOrder(w.hWnd)
-								child.Layout(base.Tight(base.Size{
+								base.Layout(child, base.Tight(base.Size{
 									Width:  w.cachedBounds.Dx(),
 									Height: w.cachedBounds.Dy(),
 								}))
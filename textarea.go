@@ -22,6 +22,7 @@ type TextArea struct {
 	OnChange    func(value string) // OnChange will be called whenever the user changes the value for this field
 	OnFocus     func()             // OnFocus will be called whenever the field receives the keyboard focus
 	OnBlur      func()             // OnBlur will be called whenever the field loses the keyboard focus
+	Key         string             // Key identifies the field when reconciling a list of children (see base.Keyed)
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -37,6 +38,16 @@ func (w *TextArea) Mount(parent base.Control) (base.Element, error) {
 	return w.mount(parent)
 }
 
+// GetKey returns the key for use in the Keyed interface.
+// Users should not need to use this method directly.
+func (w *TextArea) GetKey() string {
+	return w.Key
+}
+
+func (w *textareaElement) GetKey() string {
+	return w.key
+}
+
 func (*textareaElement) Kind() *base.Kind {
 	return &textareaKind
 }
@@ -0,0 +1,94 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type textareaElement struct {
+	Control
+
+	value       string
+	placeholder string
+	disabled    bool
+	readOnly    bool
+	minLines    int
+	onChange    func(string)
+	key         string
+}
+
+func (w *TextArea) mount(parent base.Control) (base.Element, error) {
+	retval := &textareaElement{
+		key: w.Key,
+	}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *textareaElement) Layout(bc base.Constraints) base.Size {
+	if !bc.HasBoundedWidth() {
+		width := max(bc.Min.Width, w.MinIntrinsicWidth(0))
+		height := w.MinIntrinsicHeight(width)
+		return bc.Constrain(base.Size{width, height})
+	}
+
+	width := bc.Max.Width
+	height := w.MinIntrinsicHeight(width)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *textareaElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	const lineHeight = 16 * DIP
+	return 23*DIP + lineHeight.Scale(w.minLines-1, 1)
+}
+
+func (w *textareaElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 75 * DIP
+}
+
+func (w *textareaElement) Props() base.Widget {
+	return &TextArea{
+		Value:       w.value,
+		Placeholder: w.placeholder,
+		Disabled:    w.disabled,
+		ReadOnly:    w.readOnly,
+		MinLines:    w.minLines,
+		OnChange:    w.onChange,
+		OnFocus:     w.onFocus,
+		OnBlur:      w.onBlur,
+		Key:         w.key,
+	}
+}
+
+func (w *textareaElement) TypeKeys(text string) chan error {
+	return typeKeys(text, w.typeKey)
+}
+
+func (w *textareaElement) typeKey(r rune) {
+	if w.disabled || w.readOnly || focusedControl != &w.Control {
+		return
+	}
+
+	w.value += string(r)
+	if w.onChange != nil {
+		w.onChange(w.value)
+	}
+}
+
+func (w *textareaElement) updateProps(data *TextArea) error {
+	w.value = data.Value
+	w.placeholder = data.Placeholder
+	w.disabled = data.Disabled
+	w.readOnly = data.ReadOnly
+	w.minLines = minlinesDefault(data.MinLines)
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+
+	return nil
+}
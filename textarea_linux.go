@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -17,6 +20,8 @@ type textareaElement struct {
 	shChange glib.SignalHandle
 	onFocus  focusSlot
 	onBlur   blurSlot
+	key      string
+	bounds   base.Rectangle
 }
 
 func (w *TextArea) mount(parent base.Control) (base.Element, error) {
@@ -57,6 +62,7 @@ func (w *TextArea) mount(parent base.Control) (base.Element, error) {
 		frame:    swindow,
 		onChange: w.OnChange,
 		minLines: minlinesDefault(w.MinLines),
+		key:      w.Key,
 	}
 
 	control.Connect("destroy", textareaOnDestroy, retval)
@@ -91,6 +97,10 @@ func textareaOnDestroy(widget *gtk.TextView, mounted *textareaElement) {
 	mounted.handle = nil
 }
 
+func (w *textareaElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
 func (w *textareaElement) Close() {
 	if w.handle != nil {
 		w.frame.Destroy()
@@ -162,21 +172,28 @@ func (w *textareaElement) Props() base.Widget {
 		OnChange: w.onChange,
 		OnFocus:  w.onFocus.callback,
 		OnBlur:   w.onBlur.callback,
+		Key:      w.key,
 	}
 }
 
+func (w *textareaElement) setHidden(value bool) {
+	// Hide the scrolled window that contains the text view.
+	w.frame.SetVisible(!value)
+}
+
 func (w *textareaElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	pixels := bounds.Pixels()
 	syscall.SetBounds(&w.frame.Widget, pixels.Min.X, pixels.Min.Y, pixels.Dx(), pixels.Dy())
 }
 
 func (w *textareaElement) TakeFocus() bool {
-	control := Control{&w.handle.Widget}
+	control := Control{handle: &w.handle.Widget}
 	return control.TakeFocus()
 }
 
 func (w *textareaElement) TypeKeys(text string) chan error {
-	control := Control{&w.handle.Widget}
+	control := Control{handle: &w.handle.Widget}
 	return control.TypeKeys(text)
 }
 
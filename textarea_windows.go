@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -31,10 +34,11 @@ func (w *TextArea) mount(parent base.Control) (base.Element, error) {
 
 	// Create the return value.
 	retval := &textareaElement{textinputElementBase{
-		Control:  Control{hwnd},
+		Control:  Control{hWnd: hwnd},
 		onChange: w.OnChange,
 		onFocus:  w.OnFocus,
 		onBlur:   w.OnBlur,
+		key:      w.Key,
 	},
 		minlinesDefault(w.MinLines),
 	}
@@ -92,6 +96,7 @@ func (w *textareaElement) Props() base.Widget {
 		OnChange:    w.onChange,
 		OnFocus:     w.onFocus,
 		OnBlur:      w.onBlur,
+		Key:         w.key,
 	}
 }
 
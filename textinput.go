@@ -20,6 +20,7 @@ type TextInput struct {
 	OnFocus     func()             // OnFocus will be called whenever the field receives the keyboard focus
 	OnBlur      func()             // OnBlur will be called whenever the field loses the keyboard focus
 	OnEnterKey  func(value string) // OnEnterKey will be called whenever the use hits the enter key
+	Key         string             // Key identifies the field when reconciling a list of children (see base.Keyed)
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -35,6 +36,16 @@ func (w *TextInput) Mount(parent base.Control) (base.Element, error) {
 	return w.mount(parent)
 }
 
+// GetKey returns the key for use in the Keyed interface.
+// Users should not need to use this method directly.
+func (w *TextInput) GetKey() string {
+	return w.Key
+}
+
+func (w *textinputElement) GetKey() string {
+	return w.key
+}
+
 func (*textinputElement) Kind() *base.Kind {
 	return &textInputKind
 }
@@ -0,0 +1,104 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+type textinputElement struct {
+	Control
+
+	value       string
+	placeholder string
+	disabled    bool
+	password    bool
+	readOnly    bool
+	onChange    func(string)
+	onEnterKey  func(string)
+	key         string
+}
+
+func (w *TextInput) mount(parent base.Control) (base.Element, error) {
+	retval := &textinputElement{
+		key: w.Key,
+	}
+	retval.updateProps(w)
+	return retval, nil
+}
+
+func (w *textinputElement) Baseline(height base.Length) base.Length {
+	return centeredBaseline(height)
+}
+
+func (w *textinputElement) Props() base.Widget {
+	return &TextInput{
+		Value:       w.value,
+		Placeholder: w.placeholder,
+		Disabled:    w.disabled,
+		Password:    w.password,
+		ReadOnly:    w.readOnly,
+		OnChange:    w.onChange,
+		OnFocus:     w.onFocus,
+		OnBlur:      w.onBlur,
+		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
+	}
+}
+
+func (w *textinputElement) Layout(bc base.Constraints) base.Size {
+	width := w.MinIntrinsicWidth(0)
+	height := w.MinIntrinsicHeight(0)
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *textinputElement) MinIntrinsicHeight(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 23 * DIP
+}
+
+func (w *textinputElement) MinIntrinsicWidth(base.Length) base.Length {
+	// https://msdn.microsoft.com/en-us/library/windows/desktop/dn742486.aspx#sizingandspacing
+	return 75 * DIP
+}
+
+func (w *textinputElement) TypeKeys(text string) chan error {
+	return typeKeys(text, w.typeKey)
+}
+
+func (w *textinputElement) typeKey(r rune) {
+	if w.disabled || focusedControl != &w.Control {
+		return
+	}
+
+	if r == '\n' {
+		if w.onEnterKey != nil {
+			w.onEnterKey(w.value)
+		}
+		return
+	}
+
+	if w.readOnly {
+		return
+	}
+	w.value += string(r)
+	if w.onChange != nil {
+		w.onChange(w.value)
+	}
+}
+
+func (w *textinputElement) updateProps(data *TextInput) error {
+	w.value = data.Value
+	w.placeholder = data.Placeholder
+	w.disabled = data.Disabled
+	w.password = data.Password
+	w.readOnly = data.ReadOnly
+	w.canFocus = !data.Disabled
+	w.onChange = data.OnChange
+	w.onFocus = data.OnFocus
+	w.onBlur = data.OnBlur
+	w.onEnterKey = data.OnEnterKey
+
+	return nil
+}
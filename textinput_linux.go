@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -17,6 +20,7 @@ type textinputElement struct {
 	onBlur     blurSlot
 	onEnterKey func(string)
 	shEnterKey glib.SignalHandle
+	key        string
 }
 
 func (w *TextInput) mount(parent base.Control) (base.Element, error) {
@@ -32,9 +36,10 @@ func (w *TextInput) mount(parent base.Control) (base.Element, error) {
 	control.SetEditable(!w.ReadOnly)
 
 	retval := &textinputElement{
-		Control:    Control{&control.Widget},
+		Control:    Control{handle: &control.Widget},
 		onChange:   w.OnChange,
 		onEnterKey: w.OnEnterKey,
+		key:        w.Key,
 	}
 
 	control.Connect("destroy", textinputOnDestroy, retval)
@@ -77,6 +82,10 @@ func textinputOnDestroy(widget *gtk.Entry, mounted *textinputElement) {
 	mounted.handle = nil
 }
 
+func (w *textinputElement) Baseline(height base.Length) base.Length {
+	return w.baseline(height)
+}
+
 func (w *textinputElement) entry() *gtk.Entry {
 	return (*gtk.Entry)(unsafe.Pointer(w.handle))
 }
@@ -102,6 +111,7 @@ func (w *textinputElement) Props() base.Widget {
 		OnFocus:     w.onFocus.callback,
 		OnBlur:      w.onBlur.callback,
 		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
 	}
 }
 
@@ -141,3 +141,15 @@ func TestTextInputUpdateProps(t *testing.T) {
 		&TextInput{Value: "DA"},
 	})
 }
+
+func TestTextInputUpdatePropsKeyed(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&TextInput{Value: "A", Key: "a"},
+		&TextInput{Value: "B", Key: "b"},
+		&TextInput{Value: "C", Key: "c"},
+	}, []base.Widget{
+		&TextInput{Value: "D", Key: "d"},
+		&TextInput{Value: "C", Key: "c"},
+		&TextInput{Value: "AA", Key: "a"},
+	})
+}
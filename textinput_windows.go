@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -44,11 +47,12 @@ func (w *TextInput) mount(parent base.Control) (base.Element, error) {
 
 	// Create the return value.
 	retval := &textinputElement{textinputElementBase{
-		Control:    Control{hwnd},
+		Control:    Control{hWnd: hwnd},
 		onChange:   w.OnChange,
 		onFocus:    w.OnFocus,
 		onBlur:     w.OnBlur,
 		onEnterKey: w.OnEnterKey,
+		key:        w.Key,
 	}}
 
 	// Link the control back to Go for event handling
@@ -78,12 +82,17 @@ type textinputElementBase struct {
 	onFocus    func()
 	onBlur     func()
 	onEnterKey func(value string)
+	key        string
 }
 
 type textinputElement struct {
 	textinputElementBase
 }
 
+func (w *textinputElement) Baseline(height base.Length) base.Length {
+	return w.centeredBaseline(height)
+}
+
 func (w *textinputElement) Props() base.Widget {
 	return &TextInput{
 		Value:       w.Control.Text(),
@@ -95,6 +104,7 @@ func (w *textinputElement) Props() base.Widget {
 		OnFocus:     w.onFocus,
 		OnBlur:      w.onBlur,
 		OnEnterKey:  w.onEnterKey,
+		Key:         w.key,
 	}
 }
 
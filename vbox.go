@@ -31,6 +31,12 @@ func (a MainAxisAlign) IsPacked() bool {
 
 // CrossAxisAlign identifies the different types of alignment that are possible
 // along the cross axis for vertical box and horizontal box layouts.
+//
+// Alignment to a baseline uses the optional interface base.Baseliner, which
+// is implemented by elements that display a line of text, such as labels,
+// buttons, and text inputs.  Other children are aligned by their bottom edge.
+// Baselines are only meaningful along a row, so a VBox aligns its children as
+// for CrossStart.
 type CrossAxisAlign uint8
 
 // Allowed values for alignment of the cross axis in a vertical box (VBox) or
@@ -40,6 +46,7 @@ const (
 	CrossStart                        // Children will be aligned to the left or top of the box
 	CrossCenter                       // Children will be aligned in the center of the box
 	CrossEnd                          // Children will be aligned to the right or bottom of the box
+	Baseline                          // Children will be aligned so that their text shares a common baseline (HBox only)
 )
 
 // VBox describes a layout widget that arranges its child widgets into a column.
@@ -50,10 +57,17 @@ const (
 // children.  Extra space will be distributed according to the value of
 // AlignMain.  Subject to the box constraints during layout, the height should
 // match the largest minimum height of the child widgets.
+//
+// As for HBox, Spacing overrides the platform's default gaps between
+// children if UseSpacing is set, and children wrapped with Expand and a
+// non-zero Shrink factor are made shorter when the children would otherwise
+// overflow the box.
 type VBox struct {
 	AlignMain  MainAxisAlign
 	AlignCross CrossAxisAlign
 	Children   []base.Widget
+	Spacing    base.Length // Space between adjacent children, if UseSpacing is set.
+	UseSpacing bool        // If set, Spacing replaces the platform's default gaps.
 }
 
 // Kind returns the concrete type for use in the Widget interface.
@@ -65,16 +79,11 @@ func (*VBox) Kind() *base.Kind {
 // Mount creates a vertical layout for child widgets in the GUI.
 // The newly created widget will be a child of the widget specified by parent.
 func (w *VBox) Mount(parent base.Control) (base.Element, error) {
-	c := make([]base.Element, 0, len(w.Children))
-
-	// Mount all of the children
-	for _, v := range w.Children {
-		mountedChild, err := v.Mount(parent)
-		if err != nil {
-			base.CloseElements(c)
-			return nil, err
-		}
-		c = append(c, mountedChild)
+	// Mount all of the children.  If the parent's context is set to continue
+	// on error, the box is mounted even if some of the children failed.
+	c, err := base.DiffChildren(parent, nil, w.Children)
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
 	}
 
 	// Record the flex factor for all children
@@ -85,9 +94,11 @@ func (w *VBox) Mount(parent base.Control) (base.Element, error) {
 		children:     c,
 		alignMain:    w.AlignMain,
 		alignCross:   w.AlignCross,
+		spacing:      w.Spacing,
+		useSpacing:   w.UseSpacing,
 		childrenInfo: ci,
 		totalFlex:    totalFlex,
-	}, nil
+	}, err
 }
 
 type vboxElement struct {
@@ -95,10 +106,22 @@ type vboxElement struct {
 	children   []base.Element
 	alignMain  MainAxisAlign
 	alignCross CrossAxisAlign
+	spacing    base.Length
+	useSpacing bool
 
 	childrenInfo []boxElementInfo
 	totalHeight  base.Length
 	totalFlex    int
+	bounds       base.Rectangle
+	cache        base.LayoutCache
+}
+
+func (w *vboxElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *vboxElement) Children() []base.Element {
+	return w.children
 }
 
 func (w *vboxElement) Close() {
@@ -111,7 +134,24 @@ func (*vboxElement) Kind() *base.Kind {
 	return &vboxKind
 }
 
+// gap returns the space to insert between a pair of adjacent children.
+func (w *vboxElement) gap(previous, current base.Element) base.Length {
+	if w.useSpacing {
+		return w.spacing
+	}
+	return calculateVGap(previous, current)
+}
+
 func (w *vboxElement) Layout(bc base.Constraints) base.Size {
+	if size, ok := w.cache.Layout(bc); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.layout(bc)
+	w.cache.SetLayout(bc, size)
+	return size
+}
+
+func (w *vboxElement) layout(bc base.Constraints) base.Size {
 	if len(w.children) == 0 {
 		w.totalHeight = 0
 		return bc.Constrain(base.Size{})
@@ -121,7 +161,7 @@ func (w *vboxElement) Layout(bc base.Constraints) base.Size {
 	cbc := bc
 	if w.alignMain == Homogeneous {
 		count := len(w.children)
-		gap := calculateVGap(nil, nil)
+		gap := w.gap(nil, nil)
 		cbc.TightenHeight(cbc.Max.Height.Scale(1, count) - gap.Scale(count-1, count))
 	} else {
 		cbc.Min.Height = 0
@@ -142,23 +182,64 @@ func (w *vboxElement) Layout(bc base.Constraints) base.Size {
 	previous := base.Element(nil)
 	for i, v := range w.children {
 		// Determine what gap needs to be inserted between the elements.
-		if i > 0 {
-			if w.alignMain.IsPacked() {
-				height += calculateVGap(previous, v)
-			} else {
-				height += calculateVGap(nil, nil)
+		// Hidden elements are skipped, so that they do not leave a gap.
+		if !isHiddenElement(v) {
+			if previous != nil {
+				if w.alignMain.IsPacked() {
+					height += w.gap(previous, v)
+				} else {
+					height += w.gap(nil, nil)
+				}
 			}
+			previous = v
 		}
-		previous = v
 
 		// Perform layout of the element.  Track impact on width and height.
-		size := v.Layout(cbc)
+		size := base.Layout(v, cbc)
 		w.childrenInfo[i].size = size
 		height += size.Height
 		width = max(width, size.Width)
 	}
 	w.totalHeight = height
 
+	// Need to reduce height of any widgets that can shrink, if the children
+	// overflow the box.  Once a widget reaches its minimum height, the
+	// remaining overflow is divided amongst the other widgets.
+	if totalShrink(w.childrenInfo) > 0 && w.alignMain != Homogeneous && bc.HasBoundedHeight() && w.totalHeight > bc.Max.Height {
+		minHeights := make([]base.Length, len(w.children))
+		for i, v := range w.childrenInfo {
+			if v.shrink > 0 {
+				minHeights[i] = min(v.size.Height, w.children[i].MinIntrinsicHeight(cbc.Max.Width))
+			}
+		}
+
+		for w.totalHeight > bc.Max.Height {
+			overflow := w.totalHeight - bc.Max.Height
+			total := shrinkableTotal(w.childrenInfo, func(i int) bool {
+				return w.childrenInfo[i].size.Height > minHeights[i]
+			})
+			if total == 0 {
+				break
+			}
+
+			oldTotalHeight := w.totalHeight
+			for i, v := range w.childrenInfo {
+				if v.shrink > 0 && v.size.Height > minHeights[i] {
+					oldHeight := v.size.Height
+					fbc := cbc.TightenHeight(max(minHeights[i], oldHeight-overflow.Scale(v.shrink, total)))
+					size := base.Layout(w.children[i], fbc)
+					w.childrenInfo[i].size = size
+					w.totalHeight += size.Height - oldHeight
+					width = max(width, size.Width)
+				}
+			}
+			if w.totalHeight >= oldTotalHeight {
+				// No progress, possibly because of rounding.
+				break
+			}
+		}
+	}
+
 	// Need to adjust width to any widgets that have flex
 	if w.totalFlex > 0 {
 		extraHeight := base.Length(0)
@@ -173,7 +254,7 @@ func (w *vboxElement) Layout(bc base.Constraints) base.Size {
 				if v.flex > 0 {
 					oldHeight := v.size.Height
 					fbc := cbc.TightenHeight(v.size.Height + extraHeight.Scale(v.flex, w.totalFlex))
-					size := w.children[i].Layout(fbc)
+					size := base.Layout(w.children[i], fbc)
 					w.childrenInfo[i].size = size
 					w.totalHeight += size.Height - oldHeight
 				}
@@ -188,6 +269,15 @@ func (w *vboxElement) Layout(bc base.Constraints) base.Size {
 }
 
 func (w *vboxElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicWidth(height); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicWidth(height)
+	w.cache.SetMinIntrinsicWidth(height, size)
+	return size
+}
+
+func (w *vboxElement) minIntrinsicWidth(height base.Length) base.Length {
 	if len(w.children) == 0 {
 		return 0
 	}
@@ -209,17 +299,32 @@ func (w *vboxElement) MinIntrinsicWidth(height base.Length) base.Length {
 }
 
 func (w *vboxElement) MinIntrinsicHeight(width base.Length) base.Length {
-	if len(w.children) == 0 {
+	if size, ok := w.cache.MinIntrinsicHeight(width); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicHeight(width)
+	w.cache.SetMinIntrinsicHeight(width, size)
+	return size
+}
+
+func (w *vboxElement) minIntrinsicHeight(width base.Length) base.Length {
+	// Hidden elements have zero height, and do not need any gaps.
+	count := visibleCount(w.children)
+	if count == 0 {
 		return 0
 	}
 
-	size := w.children[0].MinIntrinsicHeight(width)
+	size := base.Length(0)
 	if w.alignMain.IsPacked() {
-		previous := w.children[0]
-		for _, v := range w.children[1:] {
-			// Add the preferred gap between this pair of widgets
-			size += calculateVGap(previous, v)
-			previous = v
+		previous := base.Element(nil)
+		for _, v := range w.children {
+			if !isHiddenElement(v) {
+				// Add the preferred gap between this pair of widgets
+				if previous != nil {
+					size += w.gap(previous, v)
+				}
+				previous = v
+			}
 			// Find minimum size for this widget, and update
 			size += v.MinIntrinsicHeight(width)
 		}
@@ -227,43 +332,74 @@ func (w *vboxElement) MinIntrinsicHeight(width base.Length) base.Length {
 	}
 
 	if w.alignMain == Homogeneous {
-		for _, v := range w.children[1:] {
+		for _, v := range w.children {
 			size = max(size, v.MinIntrinsicHeight(width))
 		}
 
 		// Add a minimum gap between the controls.
-		size = size.Scale(len(w.children), 1) + calculateVGap(nil, nil).Scale(len(w.children)-1, 1)
+		size = size.Scale(count, 1) + w.gap(nil, nil).Scale(count-1, 1)
 		return size
 	}
 
-	for _, v := range w.children[1:] {
+	for _, v := range w.children {
 		size += v.MinIntrinsicHeight(width)
 	}
 
 	// Add a minimum gap between the controls.
 	if w.alignMain == SpaceBetween {
-		size += calculateVGap(nil, nil).Scale(len(w.children)-1, 1)
+		size += w.gap(nil, nil).Scale(count-1, 1)
 	} else {
-		size += calculateVGap(nil, nil).Scale(len(w.children)+1, 1)
+		size += w.gap(nil, nil).Scale(count+1, 1)
 	}
 
 	return size
 }
 
+func (w *vboxElement) Props() base.Widget {
+	children := []base.Widget(nil)
+	if len(w.children) != 0 {
+		children = make([]base.Widget, 0, len(w.children))
+		for _, v := range w.children {
+			children = append(children, base.PropsOf(v))
+		}
+	}
+
+	return &VBox{
+		AlignMain:  w.alignMain,
+		AlignCross: w.alignCross,
+		Children:   children,
+		Spacing:    w.spacing,
+		UseSpacing: w.useSpacing,
+	}
+}
+
 func (w *vboxElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	if len(w.children) == 0 {
 		return
 	}
 
+	// Hidden elements do not take a share of the space, or any gaps.
+	count := visibleCount(w.children)
+
 	if w.alignMain == Homogeneous {
-		gap := calculateVGap(nil, nil)
+		gap := w.gap(nil, nil)
 		dy := bounds.Dy() + gap
-		count := len(w.children)
 
+		j := 0
 		for i, v := range w.children {
-			y1 := bounds.Min.Y + dy.Scale(i, count)
-			y2 := bounds.Min.Y + dy.Scale(i+1, count) - gap
+			if isHiddenElement(v) {
+				y := bounds.Min.Y
+				if count > 0 {
+					y += dy.Scale(j, count)
+				}
+				w.setBoundsForChild(i, v, bounds.Min.X, y, bounds.Max.X, y)
+				continue
+			}
+			y1 := bounds.Min.Y + dy.Scale(j, count)
+			y2 := bounds.Min.Y + dy.Scale(j+1, count) - gap
 			w.setBoundsForChild(i, v, bounds.Min.X, y1, bounds.Max.X, y2)
+			j++
 		}
 		return
 	}
@@ -281,13 +417,13 @@ func (w *vboxElement) SetBounds(bounds base.Rectangle) {
 		case MainEnd:
 			bounds.Min.Y = bounds.Max.Y - w.totalHeight
 		case SpaceAround:
-			extraGap = (bounds.Dy() - w.totalHeight).Scale(1, len(w.children)+1)
+			extraGap = (bounds.Dy() - w.totalHeight).Scale(1, count+1)
 			bounds.Min.Y += extraGap
-			extraGap += calculateVGap(nil, nil)
+			extraGap += w.gap(nil, nil)
 		case SpaceBetween:
-			if len(w.children) > 1 {
-				extraGap = (bounds.Dy() - w.totalHeight).Scale(1, len(w.children)-1)
-				extraGap += calculateVGap(nil, nil)
+			if count > 1 {
+				extraGap = (bounds.Dy() - w.totalHeight).Scale(1, count-1)
+				extraGap += w.gap(nil, nil)
 			} else {
 				// There are no controls between which to put the extra space.
 				// The following essentially convert SpaceBetween to SpaceAround
@@ -300,9 +436,13 @@ func (w *vboxElement) SetBounds(bounds base.Rectangle) {
 	posY := bounds.Min.Y
 	previous := base.Element(nil)
 	for i, v := range w.children {
+		if isHiddenElement(v) {
+			w.setBoundsForChild(i, v, bounds.Min.X, posY, bounds.Max.X, posY)
+			continue
+		}
 		if w.alignMain.IsPacked() {
-			if i > 0 {
-				posY += calculateVGap(previous, v)
+			if previous != nil {
+				posY += w.gap(previous, v)
 			}
 			previous = v
 		}
@@ -315,37 +455,43 @@ func (w *vboxElement) SetBounds(bounds base.Rectangle) {
 
 func (w *vboxElement) setBoundsForChild(i int, v base.Element, posX, posY, posX2, posY2 base.Length) {
 	dx := w.childrenInfo[i].size.Width
+	bounds := base.Rectangle{}
 	switch w.alignCross {
-	case CrossStart:
-		v.SetBounds(base.Rectangle{
+	case CrossStart, Baseline:
+		bounds = base.Rectangle{
 			base.Point{posX, posY},
 			base.Point{posX + dx, posY2},
-		})
+		}
 	case CrossCenter:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX + (posX2-posX-dx)/2, posY},
 			base.Point{posX + (posX2-posX+dx)/2, posY2},
-		})
+		}
 	case CrossEnd:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX2 - dx, posY},
 			base.Point{posX2, posY2},
-		})
+		}
 	case Stretch:
-		v.SetBounds(base.Rectangle{
+		bounds = base.Rectangle{
 			base.Point{posX, posY},
 			base.Point{posX2, posY2},
-		})
+		}
 	}
+
+	v.SetBounds(mirrorForDirection(w.parent, w.bounds, bounds))
 }
 
 func (w *vboxElement) updateProps(data *VBox) (err error) {
 	// Update properties
 	w.alignMain = data.AlignMain
 	w.alignCross = data.AlignCross
+	w.spacing = data.Spacing
+	w.useSpacing = data.UseSpacing
 	w.children, err = base.DiffChildren(w.parent, w.children, data.Children)
 	// Clear cached values
 	w.childrenInfo, w.totalFlex = updateFlex(w.children, w.alignMain, w.childrenInfo)
+	w.cache.Invalidate()
 	w.totalHeight = 0
 	return err
 }
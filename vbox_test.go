@@ -1,28 +1,13 @@
 package goey
 
 import (
+	"strconv"
 	"testing"
 
 	"bitbucket.org/rj/goey/base"
 	"bitbucket.org/rj/goey/mock"
 )
 
-func (w *vboxElement) Props() base.Widget {
-	children := []base.Widget(nil)
-	if len(w.children) != 0 {
-		children = make([]base.Widget, 0, len(w.children))
-		for _, v := range w.children {
-			children = append(children, v.(Proper).Props())
-		}
-	}
-
-	return &VBox{
-		AlignMain:  w.alignMain,
-		AlignCross: w.alignCross,
-		Children:   children,
-	}
-}
-
 func TestVBoxMount(t *testing.T) {
 	buttons := []base.Widget{
 		&Button{Text: "A"},
@@ -38,6 +23,15 @@ func TestVBoxMount(t *testing.T) {
 		&VBox{Children: buttons, AlignMain: SpaceAround},
 		&VBox{Children: buttons, AlignMain: SpaceBetween},
 		&VBox{Children: buttons, AlignMain: Homogeneous},
+		&VBox{Children: buttons, Spacing: 4 * DIP, UseSpacing: true},
+	)
+
+	// The props for nested containers should be recovered recursively.
+	testingMountWidgets(t,
+		&VBox{Children: []base.Widget{
+			&Padding{Insets: DefaultInsets(), Child: &Align{Child: &Button{Text: "A"}}},
+			&Expand{Child: &HBox{Children: buttons}},
+		}},
 	)
 }
 
@@ -64,9 +58,11 @@ func TestVBoxUpdateProps(t *testing.T) {
 	testingUpdateWidgets(t, []base.Widget{
 		&VBox{AlignMain: MainStart},
 		&VBox{Children: buttons, AlignMain: MainEnd, AlignCross: CrossStart},
+		&VBox{Children: buttons, AlignMain: MainEnd},
 	}, []base.Widget{
 		&VBox{Children: buttons, AlignMain: MainEnd},
 		&VBox{AlignMain: MainStart, AlignCross: CrossCenter},
+		&VBox{Children: buttons, AlignMain: MainEnd, Spacing: 4 * DIP, UseSpacing: true},
 	})
 }
 
@@ -138,6 +134,150 @@ func TestVBoxLayout(t *testing.T) {
 	}
 }
 
+func TestVBoxSpacing(t *testing.T) {
+	children := mock.NewList(base.Size{10 * DIP, 26 * DIP}, base.Size{20 * DIP, 13 * DIP})
+
+	cases := []struct {
+		alignMain  MainAxisAlign
+		useSpacing bool
+		spacing    base.Length
+		minHeight  base.Length
+		bounds     []base.Rectangle
+	}{
+		{MainStart, false, 0, 50 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 37*DIP, 40*DIP, 50*DIP),
+		}},
+		{MainStart, true, 0, 39 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 26*DIP, 40*DIP, 39*DIP),
+		}},
+		{MainStart, true, 4 * DIP, 43 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 30*DIP, 40*DIP, 43*DIP),
+		}},
+		{SpaceBetween, true, 4 * DIP, 43 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 137*DIP, 40*DIP, 150*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in := vboxElement{
+			children:     children,
+			alignMain:    v.alignMain,
+			spacing:      v.spacing,
+			useSpacing:   v.useSpacing,
+			childrenInfo: make([]boxElementInfo, len(children)),
+		}
+
+		if value := in.MinIntrinsicHeight(base.Inf); value != v.minHeight {
+			t.Errorf("Incorrect min intrinsic height on case %d, got %s, want %s", i, value, v.minHeight)
+		}
+		size := in.Layout(base.Tight(base.Size{40 * DIP, 150 * DIP}))
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+func TestVBoxHidden(t *testing.T) {
+	children := []base.Element{
+		mock.New(base.Size{10 * DIP, 26 * DIP}),
+		&visibilityElement{hidden: true, child: mock.New(base.Size{20 * DIP, 13 * DIP})},
+		mock.New(base.Size{20 * DIP, 13 * DIP}),
+	}
+
+	// The hidden child should not leave a gap between its siblings.
+	cases := []struct {
+		alignMain   MainAxisAlign
+		useSpacing  bool
+		spacing     base.Length
+		minHeight   base.Length
+		first, last base.Rectangle
+	}{
+		{MainStart, false, 0, 50 * DIP, base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 37*DIP, 40*DIP, 50*DIP)},
+		{MainStart, true, 4 * DIP, 43 * DIP, base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 30*DIP, 40*DIP, 43*DIP)},
+		{SpaceBetween, true, 4 * DIP, 43 * DIP, base.Rect(0, 0, 40*DIP, 26*DIP), base.Rect(0, 137*DIP, 40*DIP, 150*DIP)},
+		{Homogeneous, true, 4 * DIP, 56 * DIP, base.Rect(0, 0, 40*DIP, 73*DIP), base.Rect(0, 77*DIP, 40*DIP, 150*DIP)},
+	}
+
+	for i, v := range cases {
+		in := vboxElement{
+			children:     children,
+			alignMain:    v.alignMain,
+			spacing:      v.spacing,
+			useSpacing:   v.useSpacing,
+			childrenInfo: make([]boxElementInfo, len(children)),
+		}
+
+		if value := in.MinIntrinsicHeight(base.Inf); value != v.minHeight {
+			t.Errorf("Incorrect min intrinsic height on case %d, got %s, want %s", i, value, v.minHeight)
+		}
+		size := in.Layout(base.Tight(base.Size{40 * DIP, 150 * DIP}))
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		if got := children[0].Bounds(); got != v.first {
+			t.Errorf("Incorrect bounds for first child on case %d, got %s, want %s", i, got, v.first)
+		}
+		if got := children[2].Bounds(); got != v.last {
+			t.Errorf("Incorrect bounds for last child on case %d, got %s, want %s", i, got, v.last)
+		}
+	}
+}
+
+func TestVBoxShrink(t *testing.T) {
+	children := []base.Element{
+		&expandElement{
+			child:  &testingShrinkElement{mock.New(base.Size{10 * DIP, 100 * DIP}), base.Size{10 * DIP, 20 * DIP}},
+			shrink: 1,
+		},
+		&expandElement{
+			child:  &testingShrinkElement{mock.New(base.Size{10 * DIP, 60 * DIP}), base.Size{10 * DIP, 20 * DIP}},
+			shrink: 1,
+		},
+	}
+
+	cases := []struct {
+		height base.Length
+		bounds []base.Rectangle
+	}{
+		// Both children can grow, and so they share the extra space.
+		{200 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 10*DIP, 115*DIP), base.Rect(0, 125*DIP, 10*DIP, 200*DIP),
+		}},
+		// The overflow is divided equally.
+		{120 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 10*DIP, 75*DIP), base.Rect(0, 85*DIP, 10*DIP, 120*DIP),
+		}},
+		// Once a child reaches its minimum size, the remaining overflow is
+		// taken from the other child.
+		{80 * DIP, []base.Rectangle{
+			base.Rect(0, 0, 10*DIP, 50*DIP), base.Rect(0, 60*DIP, 10*DIP, 80*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		in := vboxElement{
+			children:   children,
+			alignMain:  MainStart,
+			alignCross: CrossStart,
+			spacing:    10 * DIP,
+			useSpacing: true,
+		}
+		in.childrenInfo, in.totalFlex = updateFlex(in.children, in.alignMain, nil)
+
+		size := in.Layout(base.Tight(base.Size{10 * DIP, v.height}))
+		if want := (base.Size{10 * DIP, v.height}); size != want {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, want)
+		}
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
 func TestVBoxMinIntrinsic(t *testing.T) {
 	size := func(w, h base.Length) base.Size {
 		return base.Size{w, h}
@@ -176,3 +316,68 @@ func TestVBoxMinIntrinsic(t *testing.T) {
 		}
 	}
 }
+
+// testingDeepBox creates a tree of nested boxes, alternating between
+// horizontal and vertical boxes, with count children at each level.
+func testingDeepBox(depth, count int, horizontal bool) base.Element {
+	if depth == 0 {
+		return mock.New(base.Size{13 * DIP, 11 * DIP})
+	}
+
+	children := make([]base.Element, 0, count)
+	for i := 0; i < count; i++ {
+		children = append(children, testingDeepBox(depth-1, count, !horizontal))
+	}
+	ci, totalFlex := updateFlex(children, MainStart, nil)
+
+	if horizontal {
+		return &hboxElement{
+			children:     children,
+			childrenInfo: ci,
+			totalFlex:    totalFlex,
+		}
+	}
+	return &vboxElement{
+		children:     children,
+		childrenInfo: ci,
+		totalFlex:    totalFlex,
+	}
+}
+
+func BenchmarkBoxLayout(b *testing.B) {
+	layout := func(b *testing.B, elem base.Element, invalidate bool) {
+		for i := 0; i < b.N; i++ {
+			if invalidate {
+				base.InvalidateLayout()
+			}
+			// Simulate the window being resized.
+			width := (400 + base.Length(i%64)) * DIP
+			size := elem.Layout(base.Tight(base.Size{width, 300 * DIP}))
+			elem.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		}
+	}
+
+	for _, depth := range []int{2, 4, 6} {
+		elem := testingDeepBox(depth, 3, false)
+
+		b.Run("Depth"+strconv.Itoa(depth), func(b *testing.B) {
+			b.Run("Cached", func(b *testing.B) {
+				layout(b, elem, false)
+			})
+			// Discarding the cache on every pass measures the cost of a
+			// layout after the GUI has been updated.
+			b.Run("Invalidated", func(b *testing.B) {
+				layout(b, elem, true)
+			})
+			// Ignoring the cache entirely measures the cost of the layout
+			// algorithm without any caching, for comparison.
+			b.Run("Uncached", func(b *testing.B) {
+				layoutCacheDisabled = true
+				defer func() {
+					layoutCacheDisabled = false
+				}()
+				layout(b, elem, false)
+			})
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	visibilityKind = base.NewKind("bitbucket.org/rj/goey.Visibility")
+)
+
+// Visibility describes a widget that can hide its child without removing it
+// from the GUI.  While hidden, the child's native controls remain mounted, so
+// that any state not captured by the widget's properties, such as the scroll
+// position or the selection in a text input, is preserved.  When the child is
+// shown again, the existing controls are reused.
+//
+// A hidden child is excluded from layout, and has zero size.  Boxes do not
+// insert a gap beside a hidden child.
+type Visibility struct {
+	Hidden bool        // If true, the child is hidden.
+	Child  base.Widget // Child widget.
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Visibility) Kind() *base.Kind {
+	return &visibilityKind
+}
+
+// Mount creates a visibility widget in the GUI.  The newly created widget
+// will be a child of the widget specified by parent.
+func (w *Visibility) Mount(parent base.Control) (base.Element, error) {
+	retval := &visibilityElement{
+		parent: parent,
+		hidden: w.Hidden,
+	}
+	retval.childParent = parent.WithValue(hiddenKey{}, retval)
+
+	// Mount the child
+	child, err := base.Mount(retval.childParent, w.Child)
+	if child == nil {
+		return nil, err
+	}
+	retval.child = child
+	if retval.isHidden() {
+		setHiddenElements(child, true)
+	}
+
+	return retval, err
+}
+
+// hiddenKey is the key used to find the nearest ancestor that can hide its
+// descendants.  The value must implement hiddenState.
+type hiddenKey struct{}
+
+// hiddenState is implemented by elements that can hide their children.
+type hiddenState interface {
+	// isHidden returns true if the element's children should be hidden.
+	isHidden() bool
+}
+
+// contextHidden returns true if an ancestor of the control has hidden its
+// children.  Elements that reconcile their children outside of an update
+// from their parent, such as components, must use this to hide any newly
+// mounted controls.
+func contextHidden(parent base.Control) bool {
+	if state, ok := parent.Value(hiddenKey{}).(hiddenState); ok {
+		return state.isHidden()
+	}
+	return false
+}
+
+// hideNewElements hides the native controls of elem if an ancestor of the
+// control has hidden its children.
+func hideNewElements(parent base.Control, elem base.Element) {
+	if contextHidden(parent) {
+		setHiddenElements(elem, true)
+	}
+}
+
+// isHiddenElement returns true if the element is a visibility element whose
+// child is hidden.  Boxes do not insert gaps beside hidden elements.
+func isHiddenElement(elem base.Element) bool {
+	if elem, ok := elem.(*visibilityElement); ok {
+		return elem.hidden
+	}
+	return false
+}
+
+// visibleCount returns the number of elements that are not hidden.
+func visibleCount(elems []base.Element) int {
+	count := 0
+	for _, v := range elems {
+		if !isHiddenElement(v) {
+			count++
+		}
+	}
+	return count
+}
+
+// setHiddenElements hides or shows the native controls of the element and its
+// descendants.  Hiding a control also hides any controls nested inside, so
+// the search stops at the first control along each branch.  Elements with
+// additional native controls can implement setHidden themselves.
+func setHiddenElements(elem base.Element, hidden bool) {
+	base.Walk(elem, func(elem base.Element) bool {
+		if control, ok := elem.(interface{ setHidden(bool) }); ok {
+			control.setHidden(hidden)
+			return false
+		}
+		return true
+	})
+}
+
+type visibilityElement struct {
+	parent      base.Control
+	childParent base.Control // Parent for the child, with this element in the context.
+	child       base.Element
+	hidden      bool
+	bounds      base.Rectangle
+}
+
+func (w *visibilityElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *visibilityElement) Children() []base.Element {
+	if w.child == nil {
+		return nil
+	}
+	return []base.Element{w.child}
+}
+
+func (w *visibilityElement) Close() {
+	if w.child != nil {
+		w.child.Close()
+		w.child = nil
+	}
+}
+
+func (*visibilityElement) Kind() *base.Kind {
+	return &visibilityKind
+}
+
+func (w *visibilityElement) Layout(bc base.Constraints) base.Size {
+	if w.hidden {
+		return bc.Constrain(base.Size{})
+	}
+	return base.Layout(w.child, bc)
+}
+
+func (w *visibilityElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if w.hidden {
+		return 0
+	}
+	return w.child.MinIntrinsicHeight(width)
+}
+
+func (w *visibilityElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if w.hidden {
+		return 0
+	}
+	return w.child.MinIntrinsicWidth(height)
+}
+
+func (w *visibilityElement) Props() base.Widget {
+	return &Visibility{
+		Hidden: w.hidden,
+		Child:  base.PropsOf(w.child),
+	}
+}
+
+func (w *visibilityElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	// The child keeps its last position while hidden.
+	if !w.hidden {
+		w.child.SetBounds(bounds)
+	}
+}
+
+// isHidden returns true if the child is hidden, either by this element or by
+// an ancestor.
+func (w *visibilityElement) isHidden() bool {
+	return w.hidden || contextHidden(w.parent)
+}
+
+// setHidden is called when an ancestor hides or shows its children.  The
+// child must remain hidden if this element is hidden.
+func (w *visibilityElement) setHidden(value bool) {
+	setHiddenElements(w.child, w.hidden || value)
+}
+
+func (w *visibilityElement) updateProps(data *Visibility) (err error) {
+	w.child, err = base.DiffChild(w.childParent, w.child, data.Child)
+	// Any controls created by the update will be visible, so the child is
+	// hidden again if required.
+	if data.Hidden || w.hidden != data.Hidden {
+		w.hidden = data.Hidden
+		setHiddenElements(w.child, w.isHidden())
+	}
+	return err
+}
+
+func (w *visibilityElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Visibility))
+}
@@ -0,0 +1,75 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestVisibilityMount(t *testing.T) {
+	testingMountWidgets(t,
+		&Visibility{Child: &Button{Text: "A"}},
+		&Visibility{Hidden: true, Child: &Button{Text: "B"}},
+		&Visibility{Hidden: true, Child: &VBox{Children: []base.Widget{
+			&Label{Text: "C"}, &TextInput{Value: "D"},
+		}}},
+		&Visibility{},
+	)
+}
+
+func TestVisibilityClose(t *testing.T) {
+	testingCloseWidgets(t,
+		&Visibility{Child: &Button{Text: "A"}},
+		&Visibility{Hidden: true, Child: &Button{Text: "B"}},
+	)
+}
+
+func TestVisibilityUpdateProps(t *testing.T) {
+	testingUpdateWidgets(t, []base.Widget{
+		&Visibility{Child: &Button{Text: "A"}},
+		&Visibility{Hidden: true, Child: &Button{Text: "B"}},
+		&Visibility{Hidden: true},
+	}, []base.Widget{
+		&Visibility{Hidden: true, Child: &Button{Text: "AB"}},
+		&Visibility{Child: &Button{Text: "BC"}},
+		&Visibility{Hidden: true, Child: &Label{Text: "C"}},
+	})
+}
+
+func TestVisibilityLayout(t *testing.T) {
+	size := base.Size{20 * DIP, 10 * DIP}
+	bc := base.Loose(base.Size{100 * DIP, 100 * DIP})
+
+	cases := []struct {
+		hidden bool
+		out    base.Size
+		bounds base.Rectangle
+	}{
+		{false, size, base.Rect(0, 0, 20*DIP, 10*DIP)},
+		// A hidden child has no size, and keeps its last position.
+		{true, base.Size{}, base.Rectangle{}},
+	}
+
+	for i, v := range cases {
+		child := mock.New(size)
+		elem := visibilityElement{
+			child:  child,
+			hidden: v.hidden,
+		}
+
+		if out := elem.Layout(bc); out != v.out {
+			t.Errorf("Case %d: Returned size does not match, got %v, want %v", i, out, v.out)
+		}
+		if out := elem.MinIntrinsicWidth(base.Inf); out != v.out.Width {
+			t.Errorf("Case %d: Returned min intrinsic width does not match, got %v, want %v", i, out, v.out.Width)
+		}
+		if out := elem.MinIntrinsicHeight(base.Inf); out != v.out.Height {
+			t.Errorf("Case %d: Returned min intrinsic height does not match, got %v, want %v", i, out, v.out.Height)
+		}
+		elem.SetBounds(base.Rect(0, 0, v.out.Width, v.out.Height))
+		if got := child.Bounds(); got != v.bounds {
+			t.Errorf("Case %d: Incorrect bounds for child, got %s, want %s", i, got, v.bounds)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+)
+
+// Metrics used to size text when headless.  There is no font available, so
+// every character is assumed to have the same advance.
+const (
+	textCharWidth  = 6 * DIP
+	textLineHeight = 13 * DIP
+	textBaseline   = 10 * DIP
+)
+
+var (
+	// The control that currently has the keyboard focus.
+	focusedControl *Control
+)
+
+// Control is an opaque type used as a platform-specific handle to a control
+// created using the platform GUI.  As an example, this will refer to a HWND
+// when targeting Windows, but a *GtkWidget when targeting GTK.
+//
+// When building with the tag headless, there is no platform GUI.  The control
+// instead records its bounds and tracks the keyboard focus, so that layout and
+// interactions can be verified without a display.
+//
+// Unless developping new widgets, users should not need to use this type.
+//
+// Any method's on this type will be platform specific.
+type Control struct {
+	bounds   base.Rectangle
+	canFocus bool
+	closed   bool
+	hidden   bool
+	onFocus  func()
+	onBlur   func()
+}
+
+// Bounds returns the position of the control, as set by the last call to
+// SetBounds.
+func (w *Control) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+// Close removes the element from the GUI, and frees any associated resources.
+func (w *Control) Close() {
+	if focusedControl == w {
+		w.blur()
+	}
+	w.closed = true
+}
+
+// Closed returns true if the control has been closed.
+func (w *Control) Closed() bool {
+	return w.closed
+}
+
+// Hidden returns true if the control has been hidden.
+func (w *Control) Hidden() bool {
+	return w.hidden
+}
+
+func (w *Control) setHidden(value bool) {
+	if value && focusedControl == w {
+		w.blur()
+	}
+	w.hidden = value
+}
+
+func (w *Control) blur() {
+	focusedControl = nil
+	if w.onBlur != nil {
+		w.onBlur()
+	}
+}
+
+// TakeFocus moves the keyboard focus to the control.
+func (w *Control) TakeFocus() bool {
+	// Check that the control can grab focus
+	if w.closed || w.hidden || !w.canFocus {
+		return false
+	}
+	if focusedControl == w {
+		return true
+	}
+
+	if focusedControl != nil {
+		focusedControl.blur()
+	}
+	focusedControl = w
+	if w.onFocus != nil {
+		w.onFocus()
+	}
+	return true
+}
+
+// SetBounds updates the position of the widget.
+func (w *Control) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+}
+
+// typeKeys delivers the runes in text to the callback on the GUI thread, as
+// if the string was typed by a user.
+func typeKeys(text string, callback func(rune)) chan error {
+	err := make(chan error, 1)
+
+	go func() {
+		defer close(err)
+
+		for _, r := range text {
+			e := loop.Do(func() error {
+				callback(r)
+				return nil
+			})
+			if e != nil {
+				err <- e
+				return
+			}
+		}
+	}()
+
+	return err
+}
+
+// centeredBaseline returns the baseline for a line of text that is centered
+// vertically in a control with the specified height.
+func centeredBaseline(height base.Length) base.Length {
+	return (height-textLineHeight)/2 + textBaseline
+}
+
+// measureText returns the size of the text, assuming a fixed advance for
+// every character.
+func measureText(text string) base.Size {
+	lines := strings.Split(text, "\n")
+	width := 0
+	for _, v := range lines {
+		if count := utf8.RuneCountInString(v); count > width {
+			width = count
+		}
+	}
+	return base.Size{textCharWidth.Scale(width, 1), textLineHeight.Scale(len(lines), 1)}
+}
@@ -0,0 +1,559 @@
+//go:build headless
+// +build headless
+
+package goey
+
+import (
+	"image"
+	"testing"
+
+	"bitbucket.org/rj/goey/animate"
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/loop"
+	"bitbucket.org/rj/goey/mock"
+)
+
+const (
+	// Flag indicating that the tests are running against the headless
+	// driver, and not a platform GUI.
+	testingHeadless = true
+)
+
+func TestHeadlessLayout(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &VBox{AlignCross: CrossStart, Children: []base.Widget{
+			&Button{Text: "A"},
+			&Label{Text: "Some text"},
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+		children := window.children()
+		if got, want := children[0].Bounds(), (base.Rectangle{Max: base.Point{75 * DIP, 23 * DIP}}); got != want {
+			t.Errorf("Incorrect bounds for button, got %v, want %v", got, want)
+		}
+		if got := children[1].Bounds(); got.Dx() != 9*textCharWidth || got.Dy() != textLineHeight {
+			t.Errorf("Incorrect size for label, got %v", got)
+		}
+
+		window.Close()
+		if !children[0].(*buttonElement).Closed() {
+			t.Errorf("Child not closed with window")
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessWalk(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &VBox{AlignCross: CrossStart, Children: []base.Widget{
+			&Padding{Insets: Insets{10 * DIP, 10 * DIP, 10 * DIP, 10 * DIP}, Child: &Button{Text: "A"}},
+			&Label{Text: "Some text"},
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+
+		kinds := []*base.Kind{}
+		bounds := []base.Rectangle{}
+		base.Walk(window.Child(), func(elem base.Element) bool {
+			kinds = append(kinds, elem.Kind())
+			bounds = append(bounds, elem.Bounds())
+			return true
+		})
+
+		wantKinds := []*base.Kind{&vboxKind, &paddingKind, &buttonKind, &labelKind}
+		if len(kinds) != len(wantKinds) {
+			t.Errorf("Incorrect number of elements, got %d, want %d", len(kinds), len(wantKinds))
+			return nil
+		}
+		for i, v := range wantKinds {
+			if kinds[i] != v {
+				t.Errorf("Incorrect kind for element %d, got %s, want %s", i, kinds[i], v)
+			}
+		}
+		if got, want := bounds[1], base.Rect(0, 0, 95*DIP, 43*DIP); got != want {
+			t.Errorf("Incorrect bounds for padding, got %v, want %v", got, want)
+		}
+		if got, want := bounds[2], base.Rect(10*DIP, 10*DIP, 85*DIP, 33*DIP); got != want {
+			t.Errorf("Incorrect bounds for button, got %v, want %v", got, want)
+		}
+
+		// Skipping the children of the padding should skip the button.
+		count := 0
+		base.Walk(window.Child(), func(elem base.Element) bool {
+			count++
+			return elem.Kind() != &paddingKind
+		})
+		if count != 3 {
+			t.Errorf("Incorrect number of elements visited, got %d, want %d", count, 3)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessRequestClose(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), nil)
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+
+		window.SetOnClosing(func() bool { return true })
+		window.RequestClose()
+		if window.isClosed {
+			t.Errorf("Window closed, but callback returned true")
+		}
+
+		window.SetOnClosing(nil)
+		window.RequestClose()
+		if !window.isClosed {
+			t.Errorf("Window not closed")
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessSetDPI(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &VBox{AlignCross: CrossStart, Children: []base.Widget{
+			&Button{Text: "A"},
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		if got, want := window.DPI(), (image.Point{96, 96}); got != want {
+			t.Errorf("Incorrect default DPI, got %v, want %v", got, want)
+		}
+
+		window.SetDPI(image.Point{192, 192})
+		if got, want := window.DPI(), (image.Point{192, 192}); got != want {
+			t.Errorf("Incorrect DPI, got %v, want %v", got, want)
+		}
+		if got, want := window.parent().Context.DPI(), (image.Point{192, 192}); got != want {
+			t.Errorf("Incorrect DPI in context, got %v, want %v", got, want)
+		}
+		if got, want := base.DPI, (image.Point{192, 192}); got != want {
+			t.Errorf("Incorrect global DPI after layout, got %v, want %v", got, want)
+		}
+		// Bounds are in DIPs, so should not change with the scale.
+		if got, want := window.children()[0].Bounds(), (base.Rectangle{Max: base.Point{75 * DIP, 23 * DIP}}); got != want {
+			t.Errorf("Incorrect bounds for button, got %v, want %v", got, want)
+		}
+
+		window.SetDPI(image.Point{96, 96})
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessScroll(t *testing.T) {
+	init := func() error {
+		scrolls := []base.Point{}
+		window, err := NewWindow(t.Name(), &Scroll{Vertical: true, Child: &VBox{
+			Children: []base.Widget{
+				&Button{Text: "A"},
+				&Label{Text: "Some text"},
+			},
+		}, OnScroll: func(pos base.Point) {
+			scrolls = append(scrolls, pos)
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		// Make the window too short for the contents.
+		window.Resize(base.Size{320 * DIP, 20 * DIP})
+		elem := window.Child().(*scrollElement)
+		button := elem.child.(*vboxElement).children[0]
+		if got := button.Bounds().Min; got != (base.Point{}) {
+			t.Errorf("Incorrect position for button, got %v", got)
+		}
+
+		// Scrolling past the end is limited.
+		elem.ScrollTo(base.Point{0, 1000 * DIP})
+		wantY := elem.childSize.Height - 20*DIP
+		if len(scrolls) != 1 || scrolls[0] != (base.Point{0, wantY}) {
+			t.Errorf("Incorrect calls to OnScroll, got %v", scrolls)
+		}
+		if got := button.Bounds().Min; got != (base.Point{0, -wantY}) {
+			t.Errorf("Incorrect position for button, got %v, want %v", got, base.Point{0, -wantY})
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessSplit(t *testing.T) {
+	init := func() error {
+		changes := []base.Length{}
+		window, err := NewWindow(t.Name(), &Split{
+			Position: 100 * DIP,
+			First:    &Button{Text: "A"},
+			Second:   &Button{Text: "B"},
+			OnChange: func(value base.Length) {
+				changes = append(changes, value)
+			},
+		})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+		elem := window.Child().(*splitElement)
+		if got, want := elem.second.Bounds().Min.X, 100*DIP+splitDividerSize; got != want {
+			t.Errorf("Incorrect position for second child, got %s, want %s", got, want)
+		}
+
+		// Dragging the divider moves the children.
+		elem.Drag(20 * DIP)
+		if len(changes) != 1 || changes[0] != 120*DIP {
+			t.Errorf("Incorrect calls to OnChange, got %v", changes)
+		}
+		if got, want := elem.first.Bounds().Dx(), 120*DIP; got != want {
+			t.Errorf("Incorrect width for first child, got %s, want %s", got, want)
+		}
+
+		// The divider cannot be dragged so the second child is too small.
+		elem.Drag(1000 * DIP)
+		if got, want := elem.second.Bounds().Dx(), elem.second.MinIntrinsicWidth(base.Inf); got != want {
+			t.Errorf("Incorrect width for second child, got %s, want %s", got, want)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessForm(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &Form{Rows: []FormRow{
+			{Label: "_Name", Field: &TextInput{Value: "A"}},
+			{Label: "Email _address", Field: &TextInput{Value: "B"}, Help: "Required."},
+		}})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+		elem := window.Child().(*formElement)
+		for i, v := range elem.rows {
+			if v.label.mnemonicField != elem.fields[i] {
+				t.Errorf("Label %d not associated with its field", i)
+			}
+		}
+		if got := elem.rows[1].label.text; got != "Email address" {
+			t.Errorf("Incorrect text for label, got %q", got)
+		}
+
+		// The fields are aligned in a column after the widest label.
+		labels := elem.rows[1].label.Bounds()
+		for i, v := range elem.fields {
+			if got, want := v.Bounds().Min.X, labels.Max.X+labelGap; got != want {
+				t.Errorf("Incorrect position for field %d, got %s, want %s", i, got, want)
+			}
+		}
+		if got, want := elem.rows[1].help.Bounds().Min.Y, elem.fields[1].Bounds().Max.Y+labelGap; got != want {
+			t.Errorf("Incorrect position for help text, got %s, want %s", got, want)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessVisibility(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &Visibility{
+			Hidden: true,
+			Child:  &TextInput{Value: "A"},
+		})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		elem := window.Child().(*visibilityElement)
+		input := elem.child.(*textinputElement)
+		if !input.Hidden() {
+			t.Errorf("Child should be hidden")
+		}
+		if input.TakeFocus() {
+			t.Errorf("Hidden child should not take focus")
+		}
+
+		// Showing the child reuses the existing control.
+		err = window.SetChild(&Visibility{Child: &TextInput{Value: "A"}})
+		if err != nil {
+			t.Errorf("Failed to update window, %s", err)
+		}
+		if elem.child != input {
+			t.Errorf("Child was not preserved")
+		}
+		if input.Hidden() {
+			t.Errorf("Child should be visible")
+		}
+		if !input.TakeFocus() {
+			t.Errorf("Visible child should take focus")
+		}
+
+		// Hiding the child moves the focus away.
+		err = window.SetChild(&Visibility{Hidden: true, Child: &TextInput{Value: "A"}})
+		if err != nil {
+			t.Errorf("Failed to update window, %s", err)
+		}
+		if !input.Hidden() || input.Closed() {
+			t.Errorf("Child should be hidden, but not closed")
+		}
+		if focusedControl != nil {
+			t.Errorf("Hidden child should not keep the focus")
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessVisibilityRerender(t *testing.T) {
+	init := func() error {
+		state := (*ComponentState)(nil)
+		component := &Component{
+			Init: func() interface{} { return false },
+			Render: func(s *ComponentState) base.Widget {
+				state = s
+				if s.Value.(bool) {
+					return &Button{Text: "A"}
+				}
+				return &Label{Text: "A"}
+			},
+		}
+
+		for i, v := range []base.Widget{
+			&Visibility{Hidden: true, Child: component},
+			&Expander{Text: "A", Child: component},
+			&Visibility{Hidden: true, Child: &Visibility{Child: component}},
+		} {
+			window, err := NewWindow(t.Name(), v)
+			if err != nil {
+				t.Errorf("Case %d: Failed to create window, %s", i, err)
+				return nil
+			}
+
+			// A control mounted when the component renders again must also
+			// be hidden.
+			err = state.Set(true)
+			if err != nil {
+				t.Errorf("Case %d: Failed to update component, %s", i, err)
+			}
+			found := false
+			base.Walk(window.Child(), func(elem base.Element) bool {
+				if button, ok := elem.(*buttonElement); ok {
+					found = true
+					if !button.Hidden() {
+						t.Errorf("Case %d: Button should be hidden", i)
+					}
+				}
+				return true
+			})
+			if !found {
+				t.Errorf("Case %d: Button was not mounted", i)
+			}
+			window.Close()
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessTabsRightToLeft(t *testing.T) {
+	child := mock.New(base.Size{10 * DIP, 10 * DIP})
+	elem := tabsElement{
+		parent: base.Control{Context: (*base.Context)(nil).WithDirection(base.RightToLeft)},
+		child:  child,
+		insets: Insets{Top: 1 * DIP, Right: 2 * DIP, Bottom: 3 * DIP, Left: 4 * DIP},
+	}
+
+	// The insets for the left and right should be swapped.
+	elem.SetBounds(base.Rect(0, 0, 100*DIP, 100*DIP))
+	if got, want := child.Bounds(), base.Rect(2*DIP, 24*DIP, 96*DIP, 97*DIP); got != want {
+		t.Errorf("Incorrect bounds for child, got %s, want %s", got, want)
+	}
+}
+
+func TestHeadlessGroupBox(t *testing.T) {
+	init := func() error {
+		window, err := NewWindow(t.Name(), &GroupBox{
+			Text:  "Options",
+			Child: &Checkbox{Text: "A"},
+		})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+		elem := window.Child().(*groupboxElement)
+		caption := measureText("Options")
+
+		// The child is placed inside the frame, below the caption.
+		frame := elem.Bounds()
+		child := elem.child.(*checkboxElement).Bounds()
+		want := base.Rect(
+			frame.Min.X+groupboxPadding, frame.Min.Y+caption.Height+labelGap,
+			frame.Max.X-groupboxPadding, frame.Max.Y-groupboxPadding,
+		)
+		if child != want {
+			t.Errorf("Incorrect bounds for child, got %s, want %s", child, want)
+		}
+		if got, want := elem.MinIntrinsicHeight(base.Inf), caption.Height+labelGap+elem.child.MinIntrinsicHeight(base.Inf)+groupboxPadding; got != want {
+			t.Errorf("Incorrect min intrinsic height, got %s, want %s", got, want)
+		}
+
+		// A long caption sets the minimum width.
+		err = window.SetChild(&GroupBox{
+			Text:  "A caption that is much wider than the child",
+			Child: &Checkbox{Text: "A"},
+		})
+		if err != nil {
+			t.Errorf("Failed to update window, %s", err)
+		}
+		caption = measureText("A caption that is much wider than the child")
+		if got, want := elem.MinIntrinsicWidth(base.Inf), caption.Width+2*groupboxPadding; got != want {
+			t.Errorf("Incorrect min intrinsic width, got %s, want %s", got, want)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
+
+func TestHeadlessExpander(t *testing.T) {
+	init := func() error {
+		changes := []bool(nil)
+		window, err := NewWindow(t.Name(), &Expander{
+			Text:     "Advanced",
+			Child:    &Checkbox{Text: "A"},
+			OnChange: func(value bool) { changes = append(changes, value) },
+		})
+		if err != nil {
+			t.Errorf("Failed to create window, %s", err)
+			return nil
+		}
+		defer window.Close()
+
+		window.Resize(base.Size{320 * DIP, 240 * DIP})
+		elem := window.Child().(*expanderElement)
+		child := elem.child.(*checkboxElement)
+		header := elem.header.size().Height
+
+		// While collapsed, the child is hidden and takes no space.
+		if !child.Hidden() {
+			t.Errorf("Child should be hidden while collapsed")
+		}
+		if got := elem.MinIntrinsicHeight(base.Inf); got != header {
+			t.Errorf("Incorrect min intrinsic height, got %s, want %s", got, header)
+		}
+
+		// Expanding shows the child below the header.
+		elem.Click()
+		if len(changes) != 1 || !changes[0] {
+			t.Errorf("Incorrect calls to OnChange, got %v", changes)
+		}
+		if child.Hidden() {
+			t.Errorf("Child should be visible while expanded")
+		}
+		if got, want := elem.MinIntrinsicHeight(base.Inf), header+labelGap+child.MinIntrinsicHeight(base.Inf); got != want {
+			t.Errorf("Incorrect min intrinsic height, got %s, want %s", got, want)
+		}
+		if got, want := child.Bounds().Min.Y, elem.Bounds().Min.Y+header+labelGap; got != want {
+			t.Errorf("Incorrect position for child, got %s, want %s", got, want)
+		}
+
+		// With animation, the child is hidden until the height has settled.
+		err = window.SetChild(&Expander{
+			Text:     "Advanced",
+			Expanded: false,
+			Animate:  true,
+			Child:    &Checkbox{Text: "A"},
+		})
+		if err != nil {
+			t.Errorf("Failed to update window, %s", err)
+		}
+		if !elem.animating || !child.Hidden() {
+			t.Errorf("Expander should be animating with a hidden child")
+		}
+		// Skip to a time well after the end of the animation.
+		elem.AnimateFrame(^animate.Time(0))
+		if elem.animating {
+			t.Errorf("Animation should be complete")
+		}
+		if got := elem.MinIntrinsicHeight(base.Inf); got != header {
+			t.Errorf("Incorrect min intrinsic height, got %s, want %s", got, header)
+		}
+		return nil
+	}
+
+	err := loop.Run(init)
+	if err != nil {
+		t.Errorf("Failed to run GUI loop, %s", err)
+	}
+}
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -20,6 +23,14 @@ import (
 // Any method's on this type will be platform specific.
 type Control struct {
 	handle *gtk.Widget
+	bounds base.Rectangle
+}
+
+// Bounds returns the position of the control, relative to its parent, as set
+// by the last call to SetBounds.  The position is not rounded to whole pixels,
+// so that it matches the bounds of elements without a native control.
+func (w *Control) Bounds() base.Rectangle {
+	return w.bounds
 }
 
 // Close removes the element from the GUI, and frees any associated resources.
@@ -35,6 +46,11 @@ func (w *Control) Handle() *gtk.Widget {
 	return w.handle
 }
 
+// setHidden is a wrapper around SetVisible.
+func (w *Control) setHidden(value bool) {
+	w.handle.SetVisible(!value)
+}
+
 // TakeFocus is a wrapper around GrabFocus.
 func (w *Control) TakeFocus() bool {
 	// Check that the control can grab focus
@@ -76,6 +92,18 @@ func (w *Control) TypeKeys(text string) chan error {
 	return err
 }
 
+// baseline returns the baseline reported by GTK, adjusted for the height of
+// the control.  GTK reports the baseline for the natural height, and centers
+// the contents when a control is taller.  If the control does not report a
+// baseline, the bottom edge is used.
+func (w *Control) baseline(height base.Length) base.Length {
+	_, natural, _, baseline := syscall.WidgetGetPreferredHeightAndBaselineForWidth(w.handle, -1)
+	if baseline < 0 {
+		return height
+	}
+	return base.FromPixelsY(baseline) + (height-base.FromPixelsY(natural))/2
+}
+
 // Layout determines the best size for an element that satisfies the
 // constraints.
 func (w *Control) Layout(bc base.Constraints) base.Size {
@@ -130,6 +158,7 @@ func (w *Control) MinIntrinsicWidth(base.Length) base.Length {
 
 // SetBounds updates the position of the widget.
 func (w *Control) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	pixels := bounds.Pixels()
 	if pixels.Dx() <= 0 || pixels.Dy() <= 0 {
 		panic("internal error.  zero width or zero height bounds for control")
@@ -0,0 +1,10 @@
+//go:build !headless
+// +build !headless
+
+package goey
+
+const (
+	// Flag indicating that the tests are running against the headless
+	// driver, and not a platform GUI.
+	testingHeadless = false
+)
@@ -1,3 +1,6 @@
+//go:build !headless
+// +build !headless
+
 package goey
 
 import (
@@ -33,7 +36,8 @@ func init() {
 //
 // Any method's on this type will be platform specific.
 type Control struct {
-	hWnd win.HWND
+	hWnd   win.HWND
+	bounds base.Rectangle
 }
 
 // Text copies text of the underlying window
@@ -54,13 +58,51 @@ func (w Control) CalcRect(text []uint16) (int32, int32) {
 	return rect.Right, rect.Bottom
 }
 
+// textMetrics returns the ascent and the height for a line of text using the
+// message font.  This is a wrapper around the WIN32 call GetTextMetrics.
+func (w Control) textMetrics() (ascent, height base.Length) {
+	hdc := win.GetDC(w.hWnd)
+	if hMessageFont != 0 {
+		win.SelectObject(hdc, win.HGDIOBJ(hMessageFont))
+	}
+	tm := win.TEXTMETRIC{}
+	win.GetTextMetrics(hdc, &tm)
+	win.ReleaseDC(w.hWnd, hdc)
+
+	return base.FromPixelsY(int(tm.TmAscent)), base.FromPixelsY(int(tm.TmHeight))
+}
+
+// centeredBaseline returns the baseline for a line of text that is centered
+// vertically in the control, when the control has the specified height.
+func (w Control) centeredBaseline(height base.Length) base.Length {
+	ascent, lineHeight := w.textMetrics()
+	return (height-lineHeight)/2 + ascent
+}
+
 // SetDisabled is a wrapper around the WIN32 call to EnableWindow.
 func (w Control) SetDisabled(value bool) {
 	win.EnableWindow(w.hWnd, !value)
 }
 
+// setHidden is a wrapper around the WIN32 call to ShowWindow.
+func (w *Control) setHidden(value bool) {
+	if value {
+		win.ShowWindow(w.hWnd, win.SW_HIDE)
+	} else {
+		win.ShowWindow(w.hWnd, win.SW_SHOWNA)
+	}
+}
+
+// Bounds returns the position of the control, relative to its parent, as set
+// by the last call to SetBounds.  The position is not rounded to whole pixels,
+// so that it matches the bounds of elements without a native control.
+func (w *Control) Bounds() base.Rectangle {
+	return w.bounds
+}
+
 // SetBounds is a wrapper around the WIN32 call to MoveWindow.
 func (w *Control) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
 	win.MoveWindow(w.hWnd, int32(bounds.Min.X.PixelsX()), int32(bounds.Min.Y.PixelsY()), int32(bounds.Dx().PixelsX()), int32(bounds.Dy().PixelsY()), false)
 }
 
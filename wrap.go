@@ -0,0 +1,304 @@
+package goey
+
+import (
+	"bitbucket.org/rj/goey/base"
+)
+
+var (
+	wrapKind = base.NewKind("bitbucket.org/rj/goey.Wrap")
+)
+
+// Wrap describes a layout widget that arranges its child widgets into rows,
+// starting a new row whenever the next child would not fit in the available
+// width.  It is suitable for lists of tags, or for a toolbar of buttons.
+//
+// The children in each row are positioned according to AlignMain, in the same
+// manner as for an HBox, except that Homogeneous is treated as SpaceAround.
+// Within each row, children are aligned according to AlignCross.  Baselines
+// are not supported, and so Baseline is treated as CrossStart.
+//
+// The gaps between children, and between rows, follow the same rules as for
+// HBox and VBox.
+type Wrap struct {
+	AlignMain  MainAxisAlign
+	AlignCross CrossAxisAlign
+	Children   []base.Widget
+}
+
+// Kind returns the concrete type for use in the Widget interface.
+// Users should not need to use this method directly.
+func (*Wrap) Kind() *base.Kind {
+	return &wrapKind
+}
+
+// Mount creates a wrapping layout for child widgets in the GUI.
+// The newly created widget will be a child of the widget specified by parent.
+func (w *Wrap) Mount(parent base.Control) (base.Element, error) {
+	// Mount all of the children.  If the parent's context is set to continue
+	// on error, the layout is mounted even if some of the children failed.
+	c, err := base.DiffChildren(parent, nil, w.Children)
+	if err != nil && !parent.Context.ContinueOnError() {
+		return nil, err
+	}
+
+	return &wrapElement{
+		parent:     parent,
+		children:   c,
+		alignMain:  w.AlignMain,
+		alignCross: w.AlignCross,
+	}, err
+}
+
+// wrapLine records the children placed in a single row.
+type wrapLine struct {
+	start, end    int
+	width, height base.Length
+}
+
+type wrapElement struct {
+	parent     base.Control
+	children   []base.Element
+	alignMain  MainAxisAlign
+	alignCross CrossAxisAlign
+
+	sizes  []base.Size
+	lines  []wrapLine
+	bounds base.Rectangle
+	cache  base.LayoutCache
+}
+
+func (w *wrapElement) Bounds() base.Rectangle {
+	return w.bounds
+}
+
+func (w *wrapElement) Children() []base.Element {
+	return w.children
+}
+
+func (w *wrapElement) Close() {
+	base.CloseElements(w.children)
+	w.children = nil
+	w.sizes = nil
+	w.lines = nil
+}
+
+func (*wrapElement) Kind() *base.Kind {
+	return &wrapKind
+}
+
+// hgap returns the gap between a pair of children on the same line.
+func (w *wrapElement) hgap(previous, current base.Element) base.Length {
+	if w.alignMain.IsPacked() {
+		return calculateHGap(previous, current)
+	}
+	return calculateHGap(nil, nil)
+}
+
+// breakLines divides the children into lines, so that no line is wider than
+// width, unless the line only contains a single child.  The function size
+// returns the size of each child.
+func (w *wrapElement) breakLines(width base.Length, size func(int) base.Size) []wrapLine {
+	lines := []wrapLine(nil)
+	line := wrapLine{}
+	for i, v := range w.children {
+		s := size(i)
+		if i > line.start {
+			gap := w.hgap(w.children[i-1], v)
+			if line.width+gap+s.Width > width {
+				// Start a new line.
+				line.end = i
+				lines = append(lines, line)
+				line = wrapLine{start: i}
+			} else {
+				line.width += gap
+			}
+		}
+		line.width += s.Width
+		line.height = max(line.height, s.Height)
+	}
+	if len(w.children) > 0 {
+		line.end = len(w.children)
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func (w *wrapElement) Layout(bc base.Constraints) base.Size {
+	if size, ok := w.cache.Layout(bc); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.layout(bc)
+	w.cache.SetLayout(bc, size)
+	return size
+}
+
+func (w *wrapElement) layout(bc base.Constraints) base.Size {
+	if len(w.children) == 0 {
+		w.sizes = w.sizes[:0]
+		w.lines = nil
+		return bc.Constrain(base.Size{})
+	}
+
+	// Determine the natural size of each child, and then divide the children
+	// into lines.
+	if cap(w.sizes) >= len(w.children) {
+		w.sizes = w.sizes[:len(w.children)]
+	} else {
+		w.sizes = make([]base.Size, len(w.children))
+	}
+	cbc := base.Loose(base.Size{bc.Max.Width, base.Inf})
+	for i, v := range w.children {
+		w.sizes[i] = base.Layout(v, cbc)
+	}
+	w.lines = w.breakLines(bc.Max.Width, func(i int) base.Size {
+		return w.sizes[i]
+	})
+
+	// Stretched children need to fill the height of their line.
+	if w.alignCross == Stretch {
+		for _, line := range w.lines {
+			for i := line.start; i < line.end; i++ {
+				w.sizes[i] = base.Layout(w.children[i], base.Tight(base.Size{w.sizes[i].Width, line.height}))
+			}
+		}
+	}
+
+	width := base.Length(0)
+	height := calculateVGap(nil, nil).Scale(len(w.lines)-1, 1)
+	for _, line := range w.lines {
+		width = max(width, line.width)
+		height += line.height
+	}
+	return bc.Constrain(base.Size{width, height})
+}
+
+func (w *wrapElement) MinIntrinsicHeight(width base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicHeight(width); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicHeight(width)
+	w.cache.SetMinIntrinsicHeight(width, size)
+	return size
+}
+
+func (w *wrapElement) minIntrinsicHeight(width base.Length) base.Length {
+	if len(w.children) == 0 {
+		return 0
+	}
+
+	// The number of lines depends on the width, so the lines need to be
+	// broken using the minimum width of each child.
+	lines := w.breakLines(width, func(i int) base.Size {
+		v := w.children[i]
+		childWidth := v.MinIntrinsicWidth(base.Inf)
+		return base.Size{childWidth, v.MinIntrinsicHeight(min(childWidth, width))}
+	})
+
+	height := calculateVGap(nil, nil).Scale(len(lines)-1, 1)
+	for _, line := range lines {
+		height += line.height
+	}
+	return height
+}
+
+func (w *wrapElement) MinIntrinsicWidth(height base.Length) base.Length {
+	if size, ok := w.cache.MinIntrinsicWidth(height); ok && !layoutCacheDisabled {
+		return size
+	}
+	size := w.minIntrinsicWidth(height)
+	w.cache.SetMinIntrinsicWidth(height, size)
+	return size
+}
+
+func (w *wrapElement) minIntrinsicWidth(height base.Length) base.Length {
+	// At the narrowest, every child is placed on its own line.
+	size := base.Length(0)
+	for _, v := range w.children {
+		size = max(size, v.MinIntrinsicWidth(base.Inf))
+	}
+	return size
+}
+
+func (w *wrapElement) Props() base.Widget {
+	children := []base.Widget(nil)
+	if len(w.children) != 0 {
+		children = make([]base.Widget, 0, len(w.children))
+		for _, v := range w.children {
+			children = append(children, base.PropsOf(v))
+		}
+	}
+
+	return &Wrap{
+		AlignMain:  w.alignMain,
+		AlignCross: w.alignCross,
+		Children:   children,
+	}
+}
+
+func (w *wrapElement) SetBounds(bounds base.Rectangle) {
+	w.bounds = bounds
+	if len(w.children) == 0 {
+		return
+	}
+	if len(w.sizes) != len(w.children) {
+		// Layout has not been performed, so the lines are not known.
+		w.layout(base.Loose(base.Size{bounds.Dx(), bounds.Dy()}))
+	}
+
+	posY := bounds.Min.Y
+	for _, line := range w.lines {
+		w.setBoundsForLine(line, bounds.Min.X, posY, bounds.Max.X)
+		posY += line.height + calculateVGap(nil, nil)
+	}
+}
+
+func (w *wrapElement) setBoundsForLine(line wrapLine, posX, posY, posX2 base.Length) {
+	// Adjust the starting position so that the line is aligned, and
+	// calculate the extra gap for the non-packed alignments.
+	count := line.end - line.start
+	extraGap := base.Length(0)
+	switch w.alignMain {
+	case MainCenter:
+		posX += (posX2 - posX - line.width) / 2
+	case MainEnd:
+		posX = posX2 - line.width
+	case SpaceAround, Homogeneous:
+		extraGap = (posX2 - posX - line.width).Scale(1, count+1)
+		posX += extraGap
+	case SpaceBetween:
+		if count > 1 {
+			extraGap = (posX2 - posX - line.width).Scale(1, count-1)
+		} else {
+			posX += (posX2 - posX - line.width) / 2
+		}
+	}
+
+	for i := line.start; i < line.end; i++ {
+		v := w.children[i]
+		if i > line.start {
+			posX += w.hgap(w.children[i-1], v) + extraGap
+		}
+
+		size := w.sizes[i]
+		y1, y2 := alignInCell(w.alignCross, posY, posY+line.height, size.Height)
+		bounds := base.Rect(posX, y1, posX+size.Width, y2)
+		v.SetBounds(mirrorForDirection(w.parent, w.bounds, bounds))
+		posX += size.Width
+	}
+}
+
+func (w *wrapElement) updateProps(data *Wrap) (err error) {
+	// Update properties
+	w.alignMain = data.AlignMain
+	w.alignCross = data.AlignCross
+	w.children, err = base.DiffChildren(w.parent, w.children, data.Children)
+	// Clear cached values
+	w.cache.Invalidate()
+	w.sizes = w.sizes[:0]
+	w.lines = nil
+	return err
+}
+
+func (w *wrapElement) UpdateProps(data base.Widget) error {
+	return w.updateProps(data.(*Wrap))
+}
@@ -0,0 +1,141 @@
+package goey
+
+import (
+	"testing"
+
+	"bitbucket.org/rj/goey/base"
+	"bitbucket.org/rj/goey/mock"
+)
+
+func TestWrapMount(t *testing.T) {
+	buttons := []base.Widget{
+		&Button{Text: "A"},
+		&Button{Text: "B"},
+		&Button{Text: "C"},
+	}
+
+	testingMountWidgets(t,
+		&Wrap{},
+		&Wrap{Children: buttons, AlignMain: MainStart},
+		&Wrap{Children: buttons, AlignMain: MainCenter},
+		&Wrap{Children: buttons, AlignMain: MainEnd},
+		&Wrap{Children: buttons, AlignMain: SpaceAround},
+		&Wrap{Children: buttons, AlignMain: SpaceBetween},
+		&Wrap{Children: buttons, AlignCross: CrossCenter},
+	)
+}
+
+func TestWrapClose(t *testing.T) {
+	buttons := []base.Widget{
+		&Button{Text: "A"},
+		&Button{Text: "B"},
+		&Button{Text: "C"},
+	}
+
+	testingCloseWidgets(t,
+		&Wrap{},
+		&Wrap{Children: buttons, AlignMain: MainStart},
+	)
+}
+
+func TestWrapUpdateProps(t *testing.T) {
+	buttons := []base.Widget{
+		&Button{Text: "A"},
+		&Button{Text: "B"},
+		&Button{Text: "C"},
+	}
+	widgets := []base.Widget{
+		&Label{Text: "AA"},
+		&Button{Text: "BA"},
+		&Label{Text: "CA"},
+		&Button{Text: "DA"},
+	}
+
+	testingUpdateWidgets(t, []base.Widget{
+		&Wrap{AlignMain: MainStart},
+		&Wrap{Children: buttons, AlignMain: MainEnd, AlignCross: CrossStart},
+		&Wrap{Children: widgets},
+	}, []base.Widget{
+		&Wrap{Children: buttons, AlignMain: MainEnd},
+		&Wrap{AlignMain: MainStart, AlignCross: CrossCenter},
+		&Wrap{Children: buttons},
+	})
+}
+
+func TestWrapLayout(t *testing.T) {
+	sizes := []base.Size{{30 * DIP, 10 * DIP}, {40 * DIP, 20 * DIP}, {50 * DIP, 10 * DIP}}
+
+	cases := []struct {
+		sizes       []base.Size
+		alignMain   MainAxisAlign
+		alignCross  CrossAxisAlign
+		constraints base.Constraints
+		size        base.Size
+		bounds      []base.Rectangle
+	}{
+		{nil, MainStart, Stretch, base.Loose(base.Size{100 * DIP, 200 * DIP}), base.Size{}, nil},
+		{sizes, MainStart, Stretch, base.Loose(base.Size{100 * DIP, 200 * DIP}), base.Size{81 * DIP, 41 * DIP}, []base.Rectangle{
+			base.Rect(0, 0, 30*DIP, 20*DIP), base.Rect(41*DIP, 0, 81*DIP, 20*DIP), base.Rect(0, 31*DIP, 50*DIP, 41*DIP),
+		}},
+		{sizes, MainEnd, CrossCenter, base.Tight(base.Size{100 * DIP, 41 * DIP}), base.Size{100 * DIP, 41 * DIP}, []base.Rectangle{
+			base.Rect(19*DIP, 5*DIP, 49*DIP, 15*DIP), base.Rect(60*DIP, 0, 100*DIP, 20*DIP), base.Rect(50*DIP, 31*DIP, 100*DIP, 41*DIP),
+		}},
+		{sizes, SpaceBetween, CrossEnd, base.Tight(base.Size{100 * DIP, 41 * DIP}), base.Size{100 * DIP, 41 * DIP}, []base.Rectangle{
+			base.Rect(0, 10*DIP, 30*DIP, 20*DIP), base.Rect(60*DIP, 0, 100*DIP, 20*DIP), base.Rect(25*DIP, 31*DIP, 75*DIP, 41*DIP),
+		}},
+		{sizes, MainStart, CrossStart, base.Loose(base.Size{base.Inf, base.Inf}), base.Size{142 * DIP, 20 * DIP}, []base.Rectangle{
+			base.Rect(0, 0, 30*DIP, 10*DIP), base.Rect(41*DIP, 0, 81*DIP, 20*DIP), base.Rect(92*DIP, 0, 142*DIP, 10*DIP),
+		}},
+	}
+
+	for i, v := range cases {
+		children := mock.NewList(v.sizes...)
+		in := wrapElement{
+			children:   children,
+			alignMain:  v.alignMain,
+			alignCross: v.alignCross,
+		}
+
+		size := in.Layout(v.constraints)
+		if size != v.size {
+			t.Errorf("Incorrect size on case %d, got %s, want %s", i, size, v.size)
+		}
+		in.SetBounds(base.Rect(0, 0, size.Width, size.Height))
+		for j, u := range v.bounds {
+			if got := children[j].Bounds(); got != u {
+				t.Errorf("Incorrect bounds case %d-%d, got %s, want %s", i, j, got, u)
+			}
+		}
+	}
+}
+
+func TestWrapMinIntrinsic(t *testing.T) {
+	sizes := []base.Size{{30 * DIP, 10 * DIP}, {40 * DIP, 20 * DIP}, {50 * DIP, 10 * DIP}}
+
+	cases := []struct {
+		sizes              []base.Size
+		width              base.Length
+		minIntrinsicWidth  base.Length
+		minIntrinsicHeight base.Length
+	}{
+		{nil, base.Inf, 0, 0},
+		{sizes, base.Inf, 50 * DIP, 20 * DIP},
+		{sizes, 142 * DIP, 50 * DIP, 20 * DIP},
+		{sizes, 100 * DIP, 50 * DIP, 41 * DIP},
+		{sizes, 50 * DIP, 50 * DIP, 62 * DIP},
+		{sizes, 0, 50 * DIP, 62 * DIP},
+	}
+
+	for i, v := range cases {
+		in := wrapElement{
+			children: mock.NewList(v.sizes...),
+		}
+
+		if value := in.MinIntrinsicHeight(v.width); value != v.minIntrinsicHeight {
+			t.Errorf("Incorrect min intrinsic height on case %d, got %s, want %s", i, value, v.minIntrinsicHeight)
+		}
+		if value := in.MinIntrinsicWidth(base.Inf); value != v.minIntrinsicWidth {
+			t.Errorf("Incorrect min intrinsic width on case %d, got %s, want %s", i, value, v.minIntrinsicWidth)
+		}
+	}
+}